@@ -0,0 +1,200 @@
+// Package animate turns a sequence of grids, like the steps of a simulation,
+// into an animated GIF or a series of numbered PNG images. Every cell is drawn
+// as a square of pixels, with the colour given by a palette.
+package animate
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+type Format string
+
+const (
+	GIF Format = "gif"
+	PNG Format = "png"
+)
+
+type Options struct {
+	Format Format
+	// Directory of the output. The GIF is written to Dir/Name.gif, and the
+	// PNG images to Dir/Name-00000.png, Dir/Name-00001.png and so on.
+	Dir  string
+	Name string
+	// Width and height of a cell, in pixels.
+	CellSize int
+	// Frames per second of the GIF, from 1 to 100.
+	FPS     int
+	Palette Palette
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Format:   GIF,
+		Dir:      "animations",
+		Name:     "animation",
+		CellSize: 4,
+		FPS:      10,
+		Palette:  DefaultPalette,
+	}
+}
+
+func (opts Options) validate() error {
+	switch {
+	case opts.Format != GIF && opts.Format != PNG:
+		return fmt.Errorf("invalid format %q, want %q or %q", opts.Format, GIF, PNG)
+	case opts.Name == "":
+		return errors.New("the animation needs a name")
+	case opts.CellSize < 1:
+		return fmt.Errorf("invalid cell size %d, want at least 1 pixel", opts.CellSize)
+	case opts.FPS < 1 || opts.FPS > 100:
+		return fmt.Errorf("invalid frame rate %d, want 1 to 100 frames per second", opts.FPS)
+	}
+	return nil
+}
+
+// Exporter draws the grids given to it, one frame each. The frames are
+// written as they come, either as PNG images or to the GIF, which is finished
+// by Close, so that long simulations do not fill the memory.
+//
+// An Exporter is also an observer of the steps of a simulation. As an
+// observer cannot fail, the first error is kept and returned by Close.
+type Exporter struct {
+	opts  Options
+	gif   *gifWriter
+	paths []string
+	count int
+	err   error
+}
+
+func New(opts Options) (*Exporter, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Exporter{opts: opts}, nil
+}
+
+// Frames returns the number of frames added so far.
+func (e *Exporter) Frames() int {
+	return e.count
+}
+
+// Add draws a grid as the next frame.
+func (e *Exporter) Add(frame grid.Grid[rune]) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	img, err := Draw(frame, e.opts.Palette, e.opts.CellSize)
+	if err == nil {
+		err = e.add(img)
+	}
+	e.err = err
+	return err
+}
+
+func (e *Exporter) add(img *image.Paletted) error {
+	defer func() { e.count++ }()
+
+	if e.opts.Format == GIF {
+		if e.gif == nil {
+			size := img.Bounds().Size()
+			path := filepath.Join(e.opts.Dir, e.opts.Name+".gif")
+			writer, err := createGIF(path, size.X, size.Y, max(1, 100/e.opts.FPS))
+			if err != nil {
+				return err
+			}
+			e.gif = writer
+		}
+		return e.gif.writeFrame(img)
+	}
+
+	path := filepath.Join(e.opts.Dir, fmt.Sprintf("%s-%05d.png", e.opts.Name, e.count))
+	if err := writeFile(path, func(f *os.File) error { return png.Encode(f, img) }); err != nil {
+		return err
+	}
+	e.paths = append(e.paths, path)
+	return nil
+}
+
+func (e *Exporter) Observe(step trace.Step[grid.Grid[rune]]) {
+	e.Add(step.State)
+}
+
+// Close finishes the GIF, and returns the paths of the written files. A GIF
+// left unfinished by an error is removed.
+func (e *Exporter) Close() ([]string, error) {
+	if e.err != nil {
+		if e.gif != nil {
+			e.gif.abort()
+		}
+		return nil, e.err
+	}
+	if e.opts.Format != GIF {
+		return e.paths, nil
+	}
+	if e.gif == nil {
+		return nil, errors.New("no frames to write")
+	}
+
+	if err := e.gif.close(); err != nil {
+		return nil, err
+	}
+	return []string{e.gif.f.Name()}, nil
+}
+
+func writeFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Draw draws a grid with every cell as a square of the given size. The image
+// has a colour for each kind of cell found in the grid, of which there can be
+// at most 256.
+func Draw(frame grid.Grid[rune], palette Palette, cellSize int) (*image.Paletted, error) {
+	indices := map[rune]uint8{}
+	colours := color.Palette{}
+	for _, cell := range frame.All() {
+		if _, found := indices[cell]; found {
+			continue
+		}
+		if len(colours) == 256 {
+			return nil, errors.New("too many kinds of cells, a frame can have at most 256")
+		}
+		indices[cell] = uint8(len(colours))
+		colours = append(colours, palette.Color(cell))
+	}
+	if len(colours) == 0 {
+		colours = append(colours, color.Black)
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, frame.Width*cellSize, frame.Height*cellSize), colours)
+	for pos, cell := range frame.All() {
+		index := indices[cell]
+		for y := pos.Y * cellSize; y < (pos.Y+1)*cellSize; y++ {
+			row := img.Pix[y*img.Stride:]
+			for x := pos.X * cellSize; x < (pos.X+1)*cellSize; x++ {
+				row[x] = index
+			}
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,286 @@
+package animate
+
+import (
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+func mustParseGrid(t *testing.T, text string) grid.Grid[rune] {
+	t.Helper()
+	cells, err := grid.ParseRunes(text)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	return cells
+}
+
+func TestDraw(t *testing.T) {
+	palette := Palette{'.': {0, 0, 0, 0xFF}, '#': {0xFF, 0xFF, 0xFF, 0xFF}}
+	img, err := Draw(mustParseGrid(t, ".#\n#."), palette, 3)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if got := img.Bounds().Size(); got.X != 6 || got.Y != 6 {
+		t.Fatalf("Got wrong size: got %v, want 6x6", got)
+	}
+	testcases := []struct {
+		X, Y int
+		Want color.RGBA
+	}{
+		{0, 0, palette['.']},
+		{2, 2, palette['.']},
+		{3, 0, palette['#']},
+		{5, 2, palette['#']},
+		{1, 4, palette['#']},
+		{4, 4, palette['.']},
+	}
+	for _, testcase := range testcases {
+		if got := img.At(testcase.X, testcase.Y); got != testcase.Want {
+			t.Errorf("Got wrong colour at %d,%d: got %v, want %v", testcase.X, testcase.Y, got, testcase.Want)
+		}
+	}
+}
+
+func TestPaletteColor(t *testing.T) {
+	palette := Palette{'#': {1, 2, 3, 0xFF}}
+	if got := palette.Color('#'); got != (color.RGBA{1, 2, 3, 0xFF}) {
+		t.Errorf("Got wrong colour for a cell of the palette: got %v", got)
+	}
+	if palette.Color('A') != palette.Color('A') {
+		t.Errorf("Got different colours for the same cell")
+	}
+	if palette.Color('A') == palette.Color('B') {
+		t.Errorf("Got the same colour for different cells: %v", palette.Color('A'))
+	}
+}
+
+func TestParsePalette(t *testing.T) {
+	got, err := ParsePalette("#=808080,.=#0000ff")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	want := Palette{'#': {0x80, 0x80, 0x80, 0xFF}, '.': {0, 0, 0xFF, 0xFF}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong palette: got %v, want %v", got, want)
+	}
+}
+
+func TestParsePaletteErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{
+			Name:    "missing colour",
+			Input:   "#",
+			WantErr: `invalid palette entry "#", want a cell and a colour like #=808080`,
+		},
+		{
+			Name:    "more than one cell",
+			Input:   "ab=000000",
+			WantErr: `invalid palette entry "ab=000000", want a cell and a colour like #=808080`,
+		},
+		{
+			Name:    "short colour",
+			Input:   "#=fff",
+			WantErr: `invalid palette entry "#=fff": want a colour of 6 hex digits, got "fff"`,
+		},
+		{
+			Name:    "not hex",
+			Input:   "#=00000g",
+			WantErr: `invalid palette entry "#=00000g": want a colour of 6 hex digits, got "00000g"`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := ParsePalette(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestExportGIF(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Dir = t.TempDir()
+	opts.Name = "day-6"
+	opts.FPS = 20
+	exporter, err := New(opts)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	exporter.Observe(trace.Step[grid.Grid[rune]]{Index: 0, State: mustParseGrid(t, "^.\n..")})
+	exporter.Observe(trace.Step[grid.Grid[rune]]{Index: 1, State: mustParseGrid(t, "X>\n..")})
+	paths, err := exporter.Close()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	wantPath := filepath.Join(opts.Dir, "day-6.gif")
+	if !reflect.DeepEqual(paths, []string{wantPath}) {
+		t.Fatalf("Got wrong paths: got %v, want %v", paths, []string{wantPath})
+	}
+	f, err := os.Open(wantPath)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if len(anim.Image) != 2 || !reflect.DeepEqual(anim.Delay, []int{5, 5}) {
+		t.Errorf("Got wrong frames: got %d frames with delays %v, want 2 frames of 5", len(anim.Image), anim.Delay)
+	}
+	if got := anim.Image[0].Bounds().Dx(); got != 2*opts.CellSize {
+		t.Errorf("Got wrong width: got %d, want %d", got, 2*opts.CellSize)
+	}
+}
+
+func TestExportGIFKeepsColours(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Dir = t.TempDir()
+	opts.CellSize = 3
+	exporter, err := New(opts)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	// Frames with different kinds of cells, large enough to span many blocks
+	// of compressed pixels.
+	frames := make([]grid.Grid[rune], 3)
+	for i := range frames {
+		frames[i] = grid.New(60, 40, '.')
+		for pos := range frames[i].All() {
+			frames[i].Set(pos, rune('a'+(pos.X*pos.Y+i)%(3+10*i)))
+		}
+		if err := exporter.Add(frames[i]); err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+	}
+	paths, err := exporter.Close()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	f, err := os.Open(paths[0])
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if len(anim.Image) != len(frames) {
+		t.Fatalf("Got wrong frames: got %d, want %d", len(anim.Image), len(frames))
+	}
+	for i, frame := range frames {
+		want, err := Draw(frame, opts.Palette, opts.CellSize)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		got := anim.Image[i]
+		for y := range want.Bounds().Dy() {
+			for x := range want.Bounds().Dx() {
+				if got.At(x, y) != want.At(x, y) {
+					t.Fatalf("Got wrong colour in frame %d at %d,%d: got %v, want %v", i, x, y, got.At(x, y), want.At(x, y))
+				}
+			}
+		}
+	}
+}
+
+func TestExportGIFErrors(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Dir = t.TempDir()
+	exporter, err := New(opts)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	exporter.Add(mustParseGrid(t, ".#\n#."))
+	err = exporter.Add(mustParseGrid(t, ".#."))
+	wantErr := "frame of 12x4 pixels in an animation of 8x8"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("Got wrong error: got %v, want %s", err, wantErr)
+	}
+	if _, err := exporter.Close(); err == nil || err.Error() != wantErr {
+		t.Errorf("Got wrong error: got %v, want %s", err, wantErr)
+	}
+	if _, err := os.Stat(filepath.Join(opts.Dir, opts.Name+".gif")); !os.IsNotExist(err) {
+		t.Errorf("Got the unfinished GIF kept: %v", err)
+	}
+}
+
+func TestExportPNG(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Format = PNG
+	opts.Dir = filepath.Join(t.TempDir(), "frames")
+	opts.Name = "day-14"
+	exporter, err := New(opts)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	for range 3 {
+		if err := exporter.Add(mustParseGrid(t, ".#.")); err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+	}
+	paths, err := exporter.Close()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(opts.Dir, "day-14-00000.png"),
+		filepath.Join(opts.Dir, "day-14-00001.png"),
+		filepath.Join(opts.Dir, "day-14-00002.png"),
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Got wrong paths: got %v, want %v", paths, want)
+	}
+	for _, path := range want {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Got unexpected error: %v", err)
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Change  func(opts *Options)
+		WantErr string
+	}{
+		{"unknown format", func(opts *Options) { opts.Format = "apng" }, `invalid format "apng", want "gif" or "png"`},
+		{"no name", func(opts *Options) { opts.Name = "" }, "the animation needs a name"},
+		{"empty cells", func(opts *Options) { opts.CellSize = 0 }, "invalid cell size 0, want at least 1 pixel"},
+		{"too fast", func(opts *Options) { opts.FPS = 200 }, "invalid frame rate 200, want 1 to 100 frames per second"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Dir = t.TempDir()
+			testcase.Change(&opts)
+			_, err := New(opts)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
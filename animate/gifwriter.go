@@ -0,0 +1,149 @@
+package animate
+
+import (
+	"bufio"
+	"compress/lzw"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+	"math/bits"
+	"os"
+)
+
+// Writes an animated GIF frame by frame, so that only the frame being written
+// is kept in memory. gif.EncodeAll needs every frame at once, which takes
+// gigabytes for the ten thousand frames of day 14. Every frame has its own
+// colour table, and the animation loops forever.
+type gifWriter struct {
+	f             *os.File
+	w             *bufio.Writer
+	width, height int
+	// Delay between the frames, in hundredths of a second.
+	delay int
+}
+
+func createGIF(path string, width, height, delay int) (*gifWriter, error) {
+	if width > 0xFFFF || height > 0xFFFF {
+		return nil, fmt.Errorf("frame of %dx%d pixels is too large for a GIF", width, height)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	g := &gifWriter{f: f, w: bufio.NewWriter(f), width: width, height: height, delay: delay}
+
+	// Header and logical screen, without a global colour table.
+	g.w.WriteString("GIF89a")
+	g.writeUint16(width, height)
+	g.w.Write([]byte{0, 0, 0})
+	// Netscape extension looping forever.
+	g.w.Write([]byte{0x21, 0xFF, 11})
+	g.w.WriteString("NETSCAPE2.0")
+	g.w.Write([]byte{3, 1, 0, 0, 0})
+	return g, nil
+}
+
+func (g *gifWriter) writeUint16(values ...int) {
+	for _, value := range values {
+		g.w.Write(binary.LittleEndian.AppendUint16(nil, uint16(value)))
+	}
+}
+
+func (g *gifWriter) writeFrame(img *image.Paletted) error {
+	size := img.Bounds().Size()
+	if size.X != g.width || size.Y != g.height {
+		return fmt.Errorf("frame of %dx%d pixels in an animation of %dx%d", size.X, size.Y, g.width, g.height)
+	}
+
+	// Graphic control extension, holding the delay.
+	g.w.Write([]byte{0x21, 0xF9, 4, 0})
+	g.writeUint16(g.delay)
+	g.w.Write([]byte{0, 0})
+
+	// Image descriptor with a local colour table of 2^tableBits colours.
+	tableBits := max(1, bits.Len(uint(len(img.Palette)-1)))
+	g.w.WriteByte(0x2C)
+	g.writeUint16(0, 0, g.width, g.height)
+	g.w.WriteByte(0x80 | byte(tableBits-1))
+	table := make([]byte, 3<<tableBits)
+	for i, c := range img.Palette {
+		r, gr, b, _ := c.RGBA()
+		table[3*i], table[3*i+1], table[3*i+2] = byte(r>>8), byte(gr>>8), byte(b>>8)
+	}
+	g.w.Write(table)
+
+	// The pixels, compressed and cut in blocks.
+	litWidth := max(2, tableBits)
+	g.w.WriteByte(byte(litWidth))
+	blocks := &blockWriter{w: g.w}
+	compressor := lzw.NewWriter(blocks, lzw.LSB, litWidth)
+	for y := range g.height {
+		start := y * img.Stride
+		if _, err := compressor.Write(img.Pix[start : start+g.width]); err != nil {
+			return err
+		}
+	}
+	if err := compressor.Close(); err != nil {
+		return err
+	}
+	return blocks.close()
+}
+
+// Writes the trailer and closes the file.
+func (g *gifWriter) close() error {
+	g.w.WriteByte(0x3B)
+	if err := g.w.Flush(); err != nil {
+		g.f.Close()
+		return err
+	}
+	return g.f.Close()
+}
+
+// Closes and removes the file after an error.
+func (g *gifWriter) abort() {
+	g.f.Close()
+	os.Remove(g.f.Name())
+}
+
+// Cuts the data into the sub-blocks of a GIF, of at most 255 bytes each after
+// their length, ended by an empty block.
+type blockWriter struct {
+	w   io.Writer
+	buf [256]byte
+	n   int
+}
+
+func (b *blockWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		copied := copy(b.buf[1+b.n:], p)
+		b.n += copied
+		written += copied
+		p = p[copied:]
+		if b.n == 255 {
+			if err := b.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (b *blockWriter) flush() error {
+	if b.n == 0 {
+		return nil
+	}
+	b.buf[0] = byte(b.n)
+	_, err := b.w.Write(b.buf[:1+b.n])
+	b.n = 0
+	return err
+}
+
+func (b *blockWriter) close() error {
+	if err := b.flush(); err != nil {
+		return err
+	}
+	_, err := b.w.Write([]byte{0})
+	return err
+}
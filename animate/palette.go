@@ -0,0 +1,96 @@
+package animate
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Palette gives the colour of each kind of cell. Cells missing from the
+// palette get a colour of their own, always the same for a given rune.
+type Palette map[rune]color.RGBA
+
+// The colours of the cells used by the simulations of the days.
+var DefaultPalette = Palette{
+	'.': {0x10, 0x10, 0x18, 0xFF}, // free space
+	'#': {0x80, 0x80, 0x88, 0xFF}, // walls, obstacles and robots of day 14
+	'X': {0x30, 0x50, 0xA0, 0xFF}, // visited cells
+	'@': {0x20, 0xE0, 0x40, 0xFF}, // robot of day 15
+	'O': {0xD0, 0x90, 0x30, 0xFF}, // boxes
+	'[': {0xD0, 0x90, 0x30, 0xFF},
+	']': {0xB0, 0x70, 0x20, 0xFF},
+	'^': {0xF0, 0x30, 0x30, 0xFF}, // guard of day 6
+	'>': {0xF0, 0x30, 0x30, 0xFF},
+	'v': {0xF0, 0x30, 0x30, 0xFF},
+	'<': {0xF0, 0x30, 0x30, 0xFF},
+}
+
+// Color returns the colour of a cell.
+func (palette Palette) Color(cell rune) color.RGBA {
+	if c, found := palette[cell]; found {
+		return c
+	}
+	// Spread the hues of consecutive runes using the golden ratio, so that
+	// neighbouring letters, like the plants of day 12, are easy to tell apart.
+	hue := math.Mod(float64(cell)*0.618033988749895, 1)
+	return hsv(hue, 0.65, 0.95)
+}
+
+// Converts a colour from HSV, all values between 0 and 1, to RGB.
+func hsv(h, s, v float64) color.RGBA {
+	sector := math.Floor(h * 6)
+	f := h*6 - sector
+	p, q, t := v*(1-s), v*(1-f*s), v*(1-(1-f)*s)
+
+	var r, g, b float64
+	switch int(sector) % 6 {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return color.RGBA{uint8(r * 255), uint8(g * 255), uint8(b * 255), 0xFF}
+}
+
+// ParsePalette parses a comma separated list of cells and hex colours, like
+// "#=808080,.=000000".
+func ParsePalette(spec string) (Palette, error) {
+	palette := Palette{}
+	if strings.TrimSpace(spec) == "" {
+		return palette, nil
+	}
+
+	for _, item := range strings.Split(spec, ",") {
+		cellText, colourText, found := strings.Cut(item, "=")
+		if !found || utf8.RuneCountInString(cellText) != 1 {
+			return nil, fmt.Errorf("invalid palette entry %q, want a cell and a colour like #=808080", item)
+		}
+		colour, err := parseHex(colourText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid palette entry %q: %w", item, err)
+		}
+		cell, _ := utf8.DecodeRuneInString(cellText)
+		palette[cell] = colour
+	}
+	return palette, nil
+}
+
+func parseHex(text string) (color.RGBA, error) {
+	text = strings.TrimPrefix(text, "#")
+	value, err := strconv.ParseUint(text, 16, 32)
+	if len(text) != 6 || err != nil {
+		return color.RGBA{}, fmt.Errorf("want a colour of 6 hex digits, got %q", text)
+	}
+	return color.RGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), 0xFF}, nil
+}
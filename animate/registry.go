@@ -0,0 +1,32 @@
+package animate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+// Animator runs the simulation of a day, telling the observer the grid after
+// every step. Like a solver, it fails if the input cannot be parsed, and stops
+// with ctx.Err() once the context is done.
+type Animator func(ctx context.Context, input string, observer trace.Observer[grid.Grid[rune]]) error
+
+var animators = map[int]Animator{}
+
+// Register adds the animation of a day. It is meant to be called from the init
+// function of the day's package, next to registry.Register, and panics if the
+// day already has an animation.
+func Register(day int, animator Animator) {
+	if _, found := animators[day]; found {
+		panic(fmt.Sprintf("day %d already has an animation", day))
+	}
+	animators[day] = animator
+}
+
+// Get returns the animation of a day, or nil for the days without a
+// simulation worth watching.
+func Get(day int) Animator {
+	return animators[day]
+}
@@ -0,0 +1,33 @@
+package animate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() { animators = map[int]Animator{} })
+	Register(6, func(context.Context, string, trace.Observer[grid.Grid[rune]]) error { return nil })
+
+	if Get(6) == nil {
+		t.Errorf("Got no animation for day 6")
+	}
+	if Get(7) != nil {
+		t.Errorf("Got an animation for day 7, want nil")
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	t.Cleanup(func() { animators = map[int]Animator{} })
+	animator := func(context.Context, string, trace.Observer[grid.Grid[rune]]) error { return nil }
+	Register(6, animator)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Registering an animation twice did not panic")
+		}
+	}()
+	Register(6, animator)
+}
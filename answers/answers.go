@@ -0,0 +1,228 @@
+// Package answers stores the answers of the solved puzzles, so that
+// the solvers can be checked for regressions after a refactor. The answers of
+// a day are kept in answers.json, next to its input in the cache.
+package answers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+)
+
+// Answers holds the answer of each part of a day. An empty answer means that
+// the part has not been solved yet. An answer is either accepted by the
+// website, or only recorded from a run of the solver, which may be wrong. The
+// answers rejected by the website are kept too, so that they are not
+// submitted again.
+type Answers struct {
+	Part1         string  `json:"part1,omitempty"`
+	Part2         string  `json:"part2,omitempty"`
+	AcceptedPart1 bool    `json:"accepted_part1,omitempty"`
+	AcceptedPart2 bool    `json:"accepted_part2,omitempty"`
+	WrongPart1    []Guess `json:"wrong_part1,omitempty"`
+	WrongPart2    []Guess `json:"wrong_part2,omitempty"`
+}
+
+// Hints given by the website along with a wrong answer.
+const (
+	TooHigh = "too high"
+	TooLow  = "too low"
+)
+
+// Guess is a wrong answer, with the hint given for it, if any.
+type Guess struct {
+	Answer string `json:"answer"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+// Get returns the answer of a part, or "" if it is not known.
+func (a Answers) Get(part int) string {
+	switch part {
+	case 1:
+		return a.Part1
+	case 2:
+		return a.Part2
+	}
+	return ""
+}
+
+// Accepted tells whether the answer of a part was accepted by the website.
+func (a Answers) Accepted(part int) bool {
+	switch part {
+	case 1:
+		return a.AcceptedPart1
+	case 2:
+		return a.AcceptedPart2
+	}
+	return false
+}
+
+// Set changes the answer of a part. A different answer is no longer accepted.
+// It panics for parts other than 1 and 2.
+func (a *Answers) Set(part int, answer string) {
+	switch part {
+	case 1:
+		a.AcceptedPart1 = a.AcceptedPart1 && a.Part1 == answer
+		a.Part1 = answer
+	case 2:
+		a.AcceptedPart2 = a.AcceptedPart2 && a.Part2 == answer
+		a.Part2 = answer
+	default:
+		panic(fmt.Sprintf("answers: invalid part %d", part))
+	}
+}
+
+// Accept sets the answer of a part, accepted by the website. It panics for
+// parts other than 1 and 2.
+func (a *Answers) Accept(part int, answer string) {
+	a.Set(part, answer)
+	switch part {
+	case 1:
+		a.AcceptedPart1 = true
+	case 2:
+		a.AcceptedPart2 = true
+	}
+}
+
+// Wrong returns the wrong answers of a part.
+func (a Answers) Wrong(part int) []Guess {
+	switch part {
+	case 1:
+		return a.WrongPart1
+	case 2:
+		return a.WrongPart2
+	}
+	return nil
+}
+
+// AddWrong keeps a wrong answer of a part. It panics for parts other than 1
+// and 2.
+func (a *Answers) AddWrong(part int, guess Guess) {
+	switch part {
+	case 1:
+		a.WrongPart1 = append(a.WrongPart1, guess)
+	case 2:
+		a.WrongPart2 = append(a.WrongPart2, guess)
+	default:
+		panic(fmt.Sprintf("answers: invalid part %d", part))
+	}
+}
+
+// KnownWrong tells whether an answer of a part is known to be wrong, and
+// returns the guess showing it. An answer is wrong if it was rejected before,
+// or if it is not below an answer that was too high, or not above an answer
+// that was too low.
+func (a Answers) KnownWrong(part int, answer string) (Guess, bool) {
+	value, err := strconv.ParseInt(answer, 10, 64)
+	isNumber := err == nil
+
+	for _, guess := range a.Wrong(part) {
+		if guess.Answer == answer {
+			return guess, true
+		}
+		if !isNumber {
+			continue
+		}
+
+		bound, err := strconv.ParseInt(guess.Answer, 10, 64)
+		if err != nil {
+			continue
+		}
+		if guess.Hint == TooHigh && value >= bound || guess.Hint == TooLow && value <= bound {
+			return guess, true
+		}
+	}
+	return Guess{}, false
+}
+
+// Format converts the value returned by a solver to the text stored as answer.
+func Format(answer any) string {
+	return fmt.Sprint(answer)
+}
+
+// Path returns the path of the answers file of a day.
+func Path(day int) (string, error) {
+	dir, err := inputs.DayDir(day)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "answers.json"), nil
+}
+
+// Load reads the answers of a day. A day without an answers file has no
+// known answers, so it is not an error.
+func Load(day int) (Answers, error) {
+	path, err := Path(day)
+	if err != nil {
+		return Answers{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Answers{}, nil
+	}
+	if err != nil {
+		return Answers{}, err
+	}
+
+	var answers Answers
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return Answers{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// Save writes the answers of a day, replacing the ones stored before.
+func Save(day int, answers Answers) error {
+	path, err := Path(day)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Record stores the answer of one part of a day found by a solver, keeping
+// the answer of the other part. It is not accepted by the website, unless it
+// was already.
+func Record(day, part int, answer string) error {
+	answers, err := Load(day)
+	if err != nil {
+		return err
+	}
+	answers.Set(part, answer)
+	return Save(day, answers)
+}
+
+// RecordAccepted stores the answer of one part of a day accepted by the
+// website, keeping the answer of the other part.
+func RecordAccepted(day, part int, answer string) error {
+	answers, err := Load(day)
+	if err != nil {
+		return err
+	}
+	answers.Accept(part, answer)
+	return Save(day, answers)
+}
+
+// RecordWrong keeps a wrong answer of one part of a day.
+func RecordWrong(day, part int, guess Guess) error {
+	answers, err := Load(day)
+	if err != nil {
+		return err
+	}
+	answers.AddWrong(part, guess)
+	return Save(day, answers)
+}
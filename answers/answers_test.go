@@ -0,0 +1,132 @@
+package answers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadWithoutAnswersFile(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+
+	got, err := Load(4)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, Answers{}) {
+		t.Errorf("Got wrong answers: got %+v, want none", got)
+	}
+}
+
+func TestRecordKeepsOtherPart(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AOC_CACHE_DIR", dir)
+
+	if err := Record(11, 1, "55312"); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if err := Record(11, 2, "65601038650482"); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	got, err := Load(11)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	want := Answers{Part1: "55312", Part2: "65601038650482"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong answers: got %+v, want %+v", got, want)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "day-11", "answers.json")); err != nil {
+		t.Errorf("Answers file is not next to the input: %v", err)
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AOC_CACHE_DIR", dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "day-2"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "day-2", "answers.json"), []byte("{part1: 2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(2); err == nil {
+		t.Errorf("Got no error for an invalid answers file")
+	}
+}
+
+func TestGetAndSet(t *testing.T) {
+	var answers Answers
+	answers.Set(2, "31")
+	if got := answers.Get(2); got != "31" {
+		t.Errorf("Got wrong answer: got %q, want %q", got, "31")
+	}
+	if got := answers.Get(1); got != "" {
+		t.Errorf("Got wrong answer: got %q, want none", got)
+	}
+}
+
+func TestAccept(t *testing.T) {
+	var answers Answers
+	answers.Set(1, "31")
+	if answers.Accepted(1) {
+		t.Errorf("Got an answer accepted without the website")
+	}
+
+	answers.Accept(1, "31")
+	answers.Set(1, "31")
+	if !answers.Accepted(1) || answers.Accepted(2) {
+		t.Errorf("Got wrong accepted parts: got %v and %v, want only part 1", answers.Accepted(1), answers.Accepted(2))
+	}
+
+	answers.Set(1, "32")
+	if answers.Accepted(1) {
+		t.Errorf("Got a changed answer still accepted")
+	}
+}
+
+func TestKnownWrong(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+
+	for _, guess := range []Guess{{"500", TooHigh}, {"100", TooLow}, {"300", ""}, {"abc", ""}} {
+		if err := RecordWrong(7, 2, guess); err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+	}
+	answers, err := Load(7)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	testcases := []struct {
+		Answer    string
+		WantWrong bool
+		WantGuess Guess
+	}{
+		{"300", true, Guess{"300", ""}},
+		{"abc", true, Guess{"abc", ""}},
+		{"500", true, Guess{"500", TooHigh}},
+		{"612", true, Guess{"500", TooHigh}},
+		{"99", true, Guess{"100", TooLow}},
+		{"250", false, Guess{}},
+		{"xyz", false, Guess{}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Answer, func(t *testing.T) {
+			gotGuess, gotWrong := answers.KnownWrong(2, testcase.Answer)
+			if gotWrong != testcase.WantWrong || gotGuess != testcase.WantGuess {
+				t.Errorf("Got wrong output: got %v %+v, want %v %+v", gotWrong, gotGuess, testcase.WantWrong, testcase.WantGuess)
+			}
+		})
+	}
+
+	if _, wrong := answers.KnownWrong(1, "300"); wrong {
+		t.Errorf("Wrong answers of part 2 are used for part 1")
+	}
+}
@@ -0,0 +1,102 @@
+// Package bench measures the registered solvers. The same measurements are
+// used by the "aoc bench" command and by the Benchmark functions of the days,
+// through testutil.BenchPart, so that both end up in the same report.
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+// Result holds the measurements of several runs of one part of a day.
+type Result struct {
+	Day          int           `json:"day"`
+	Part         int           `json:"part"`
+	Runs         int           `json:"runs"`
+	Min          time.Duration `json:"min_ns"`
+	Median       time.Duration `json:"median_ns"`
+	Max          time.Duration `json:"max_ns"`
+	AllocsPerRun uint64        `json:"allocs_per_run"`
+	BytesPerRun  uint64        `json:"bytes_per_run"`
+}
+
+// Measure runs a solver the given number of times on the input, and returns
+// the spread of the wall times and the average allocations of a run.
+func Measure(solver registry.Solver, input string, runs int) (Result, error) {
+	if runs < 1 {
+		return Result{}, fmt.Errorf("invalid number of runs %d, it should be at least 1", runs)
+	}
+
+	durations := make([]time.Duration, 0, runs)
+	var before, after runtime.MemStats
+	var allocs, bytes uint64
+	for range runs {
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		_, err := solver(context.Background(), input)
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			return Result{}, err
+		}
+
+		durations = append(durations, elapsed)
+		allocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+	}
+
+	result := Summarize(durations)
+	result.AllocsPerRun = allocs / uint64(runs)
+	result.BytesPerRun = bytes / uint64(runs)
+	return result, nil
+}
+
+// Summarize returns the number of runs and the min, median and max of their
+// durations.
+func Summarize(durations []time.Duration) Result {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return Result{Runs: n, Min: sorted[0], Median: median, Max: sorted[n-1]}
+}
+
+// WriteTable writes the results as an aligned table.
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Day\tPart\tRuns\tMin\tMedian\tMax\tAllocs/run\tBytes/run\t")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%v\t%v\t%v\t%d\t%d\t\n",
+			result.Day, result.Part, result.Runs,
+			roundDuration(result.Min), roundDuration(result.Median), roundDuration(result.Max),
+			result.AllocsPerRun, result.BytesPerRun)
+	}
+	return tw.Flush()
+}
+
+// Keeps the microseconds of long durations, and every digit of short ones.
+func roundDuration(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d
+	}
+	return d.Round(time.Microsecond)
+}
+
+// WriteJSON writes the results as an indented JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
@@ -0,0 +1,107 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	testcases := []struct {
+		Name      string
+		Durations []time.Duration
+		Want      Result
+	}{
+		{"single run", []time.Duration{5}, Result{Runs: 1, Min: 5, Median: 5, Max: 5}},
+		{"odd number of runs", []time.Duration{9, 2, 4}, Result{Runs: 3, Min: 2, Median: 4, Max: 9}},
+		{"even number of runs", []time.Duration{9, 2, 4, 6}, Result{Runs: 4, Min: 2, Median: 5, Max: 9}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got := Summarize(testcase.Durations)
+			if got != testcase.Want {
+				t.Errorf("Got wrong result: got %+v, want %+v", got, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	calls := 0
+	solver := func(ctx context.Context, input string) (any, error) {
+		calls++
+		return make([]int, 1000), nil
+	}
+
+	got, err := Measure(solver, "", 5)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if calls != 5 || got.Runs != 5 {
+		t.Errorf("Got wrong number of runs: called %d times, reported %d, want 5", calls, got.Runs)
+	}
+	if got.Min > got.Median || got.Median > got.Max {
+		t.Errorf("Got unordered times: %+v", got)
+	}
+	if got.BytesPerRun < 8000 {
+		t.Errorf("Got too few bytes per run: got %d, want at least 8000", got.BytesPerRun)
+	}
+}
+
+func TestMeasureErrors(t *testing.T) {
+	failing := func(ctx context.Context, input string) (any, error) { return nil, errors.New("bad input") }
+	if _, err := Measure(failing, "", 3); err == nil || err.Error() != "bad input" {
+		t.Errorf("Got wrong error: got %v, want bad input", err)
+	}
+	if _, err := Measure(failing, "", 0); err == nil {
+		t.Errorf("Got no error for 0 runs")
+	}
+}
+
+func TestParseGoTest(t *testing.T) {
+	output := `goos: linux
+goarch: amd64
+pkg: github.com/tejesh-kaliki/advent-of-code-2024/day-13
+cpu: Some CPU
+BenchmarkPart1-8   	    1000	   1200 ns/op	  1000 min-ns	  1100 median-ns	  3000 max-ns	  512 B/op	  10 allocs/op
+BenchmarkPart2-8   	     500	   2500 ns/op	  600 B/op	  12 allocs/op
+PASS
+ok  	github.com/tejesh-kaliki/advent-of-code-2024/day-13	3.2s
+pkg: github.com/tejesh-kaliki/advent-of-code-2024/day-7
+BenchmarkFindTotalOfValidEquationsWithConcat-8   	 10	 1000 ns/op
+BenchmarkPart1   	 20	 700 ns/op
+ok  	github.com/tejesh-kaliki/advent-of-code-2024/day-7	1.1s
+`
+	got, err := ParseGoTest(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := []Result{
+		{Day: 13, Part: 1, Runs: 1000, Min: 1000, Median: 1100, Max: 3000, AllocsPerRun: 10, BytesPerRun: 512},
+		{Day: 13, Part: 2, Runs: 500, Min: 2500, Median: 2500, Max: 2500, AllocsPerRun: 12, BytesPerRun: 600},
+		{Day: 7, Part: 1, Runs: 20, Min: 700, Median: 700, Max: 700},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong results:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	results := []Result{{Day: 1, Part: 2, Runs: 3, Min: time.Millisecond, Median: 2 * time.Millisecond, Max: 3 * time.Millisecond, AllocsPerRun: 4, BytesPerRun: 50}}
+	if err := WriteTable(&buf, results); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := "  Day  Part  Runs  Min  Median  Max  Allocs/run  Bytes/run\n" +
+		"    1     2     3  1ms     2ms  3ms           4         50\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Got wrong table:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	packageDayPattern = regexp.MustCompile(`/day-(\d+)$`)
+	benchPartPattern  = regexp.MustCompile(`^BenchmarkPart([12])(-\d+)?$`)
+)
+
+// ParseGoTest reads the output of "go test -bench", and returns the results
+// of the BenchmarkPart1 and BenchmarkPart2 functions of the days. Other
+// benchmarks are ignored.
+func ParseGoTest(r io.Reader) ([]Result, error) {
+	results := make([]Result, 0)
+	day := 0
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if pkg, found := strings.CutPrefix(line, "pkg: "); found {
+			day = 0
+			if match := packageDayPattern.FindStringSubmatch(pkg); match != nil {
+				day, _ = strconv.Atoi(match[1])
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if day == 0 || len(fields) < 4 {
+			continue
+		}
+		match := benchPartPattern.FindStringSubmatch(fields[0])
+		if match == nil {
+			continue
+		}
+
+		result, err := parseBenchLine(fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		result.Day = day
+		result.Part, _ = strconv.Atoi(match[1])
+		results = append(results, result)
+	}
+	return results, scanner.Err()
+}
+
+// Parses a line like "BenchmarkPart1-8 100 1234 ns/op 512 B/op 3 allocs/op",
+// where the values are followed by their units.
+func parseBenchLine(fields []string) (Result, error) {
+	runs, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid number of runs %q", fields[1])
+	}
+
+	metrics := make(map[string]float64)
+	for i := 2; i+1 < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return Result{}, fmt.Errorf("invalid value %q for %s", fields[i], fields[i+1])
+		}
+		metrics[fields[i+1]] = value
+	}
+
+	perOp, found := metrics["ns/op"]
+	if !found {
+		return Result{}, fmt.Errorf("benchmark %s has no ns/op", fields[0])
+	}
+	// Benchmarks that do not report the spread only have the average time.
+	duration := func(unit string) time.Duration {
+		if value, found := metrics[unit]; found {
+			return time.Duration(value)
+		}
+		return time.Duration(perOp)
+	}
+
+	return Result{
+		Runs:         runs,
+		Min:          duration("min-ns"),
+		Median:       duration("median-ns"),
+		Max:          duration("max-ns"),
+		AllocsPerRun: uint64(metrics["allocs/op"]),
+		BytesPerRun:  uint64(metrics["B/op"]),
+	}, nil
+}
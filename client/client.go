@@ -0,0 +1,134 @@
+// Package client talks to the Advent of Code website. Every request is
+// authenticated with the session cookie of the user, which is read from the
+// AOC_SESSION environment variable or from a config file.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultBaseURL is the address of the Advent of Code website.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// Year is the event whose puzzles are fetched.
+const Year = 2024
+
+const userAgent = "github.com/tejesh-kaliki/advent-of-code-2024"
+
+var (
+	// ErrNoSession is returned when no session token can be found.
+	ErrNoSession = errors.New("no session token")
+	// ErrNotUnlocked is returned for a puzzle that is not available yet.
+	ErrNotUnlocked = errors.New("puzzle is not unlocked yet")
+	// ErrBadSession is returned when the website does not accept the token.
+	ErrBadSession = errors.New("session token was rejected, it may have expired")
+)
+
+// Client sends requests to the website, or to any server at BaseURL.
+type Client struct {
+	BaseURL    string
+	Session    string
+	HTTPClient *http.Client
+}
+
+// New returns a client for the website, or for the server given by the
+// AOC_BASE_URL environment variable.
+func New(session string) *Client {
+	baseURL := os.Getenv("AOC_BASE_URL")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{BaseURL: baseURL, Session: session, HTTPClient: http.DefaultClient}
+}
+
+// ConfigPath returns the path of the file holding the session token.
+func ConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "advent-of-code-2024", "session"), nil
+}
+
+// LoadSession returns the session token from the AOC_SESSION environment
+// variable, or else from the file at ConfigPath.
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv("AOC_SESSION")); session != "" {
+		return session, nil
+	}
+
+	path, err := ConfigPath()
+	if err != nil {
+		return "", fmt.Errorf("%w: set AOC_SESSION", ErrNoSession)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: set AOC_SESSION or save the token in %s", ErrNoSession, path)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	session := strings.TrimSpace(string(data))
+	if session == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrNoSession, path)
+	}
+	return session, nil
+}
+
+// FetchInput downloads the puzzle input of a day.
+func (c *Client) FetchInput(ctx context.Context, day int) (string, error) {
+	body, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", Year, day), nil)
+	if err != nil {
+		return "", fmt.Errorf("fetching input of day %d: %w", day, err)
+	}
+	return body, nil
+}
+
+// Sends an authenticated request, and returns the body of a 200 response.
+// Other responses are turned into errors.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (string, error) {
+	if c.Session == "" {
+		return "", ErrNoSession
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return "", err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return string(data), nil
+	case resp.StatusCode == http.StatusNotFound:
+		return "", ErrNotUnlocked
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized:
+		return "", ErrBadSession
+	case resp.StatusCode >= 500:
+		return "", fmt.Errorf("server error %q, try again later", resp.Status)
+	}
+	return "", fmt.Errorf("unexpected response %q", resp.Status)
+}
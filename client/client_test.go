@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{BaseURL: server.URL, Session: "secret", HTTPClient: server.Client()}
+}
+
+func TestFetchInput(t *testing.T) {
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2024/day/3/input" {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("mul(1,2)\n"))
+	})
+
+	got, err := c.FetchInput(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got != "mul(1,2)\n" {
+		t.Errorf("Got wrong input: got %q, want %q", got, "mul(1,2)\n")
+	}
+}
+
+func TestFetchInputErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Status  int
+		Session string
+		WantErr error
+		WantMsg string
+	}{
+		{Name: "missing token", Status: http.StatusOK, WantErr: ErrNoSession},
+		{Name: "not unlocked", Status: http.StatusNotFound, Session: "secret", WantErr: ErrNotUnlocked},
+		{Name: "rejected token", Status: http.StatusBadRequest, Session: "secret", WantErr: ErrBadSession},
+		{Name: "server error", Status: http.StatusBadGateway, Session: "secret", WantMsg: `server error "502 Bad Gateway", try again later`},
+		{Name: "unexpected status", Status: http.StatusTeapot, Session: "secret", WantMsg: `unexpected response "418 I'm a teapot"`},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testcase.Status)
+			})
+			c.Session = testcase.Session
+
+			_, err := c.FetchInput(context.Background(), 25)
+			if err == nil {
+				t.Fatalf("Got no error, want %v", testcase.WantErr)
+			}
+			if testcase.WantErr != nil && !errors.Is(err, testcase.WantErr) {
+				t.Errorf("Got wrong error: got %v, want %v", err, testcase.WantErr)
+			}
+			if testcase.WantMsg != "" && !strings.HasSuffix(err.Error(), testcase.WantMsg) {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantMsg)
+			}
+			if !strings.Contains(err.Error(), "day 25") {
+				t.Errorf("Error does not mention the day: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadSession(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AOC_SESSION", "")
+
+	if _, err := LoadSession(); !errors.Is(err, ErrNoSession) {
+		t.Fatalf("Got wrong error: got %v, want %v", err, ErrNoSession)
+	}
+
+	path, err := ConfigPath()
+	if err != nil {
+		t.Skip(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := LoadSession(); err != nil || got != "from-file" {
+		t.Errorf("Got wrong session: got %q, %v, want %q", got, err, "from-file")
+	}
+
+	t.Setenv("AOC_SESSION", "from-env")
+	if got, err := LoadSession(); err != nil || got != "from-env" {
+		t.Errorf("Got wrong session: got %q, %v, want %q", got, err, "from-env")
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is the kind of response given to a submitted answer.
+type Outcome int
+
+const (
+	Unknown Outcome = iota
+	Correct
+	TooHigh
+	TooLow
+	Wrong // wrong, without telling whether it is too high or too low
+	RateLimited
+	AlreadySolved
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Correct:
+		return "correct"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case Wrong:
+		return "wrong"
+	case RateLimited:
+		return "rate limited"
+	case AlreadySolved:
+		return "already solved"
+	}
+	return "unknown"
+}
+
+// Verdict is the response of the website to a submitted answer.
+type Verdict struct {
+	Outcome Outcome
+	// Wait is how long to wait before submitting again, when rate limited or
+	// after a wrong answer.
+	Wait time.Duration
+	// Message is the text of the response, without the HTML tags.
+	Message string
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+	waitPattern    = regexp.MustCompile(`You have (?:(\d+)m ?)?(?:(\d+)s )?left to wait`)
+	penaltyPattern = regexp.MustCompile(`[Pp]lease wait (one|\d+) minutes? before trying again`)
+)
+
+// Submit posts the answer of one part of a day, and classifies the response.
+func (c *Client) Submit(ctx context.Context, day, part int, answer string) (Verdict, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	body, err := c.do(ctx, "POST", fmt.Sprintf("/%d/day/%d/answer", Year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, fmt.Errorf("submitting answer of day %d, part %d: %w", day, part, err)
+	}
+	return ParseVerdict(body), nil
+}
+
+// ParseVerdict reads the page returned for a submitted answer.
+func ParseVerdict(page string) Verdict {
+	text := page
+	if match := articlePattern.FindStringSubmatch(page); match != nil {
+		text = match[1]
+	}
+	text = html.UnescapeString(tagPattern.ReplaceAllString(text, ""))
+	text = strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+
+	verdict := Verdict{Message: text}
+	switch {
+	case strings.Contains(text, "That's the right answer"):
+		verdict.Outcome = Correct
+	case strings.Contains(text, "That's not the right answer"):
+		verdict.Wait = parseWait(text)
+		switch {
+		case strings.Contains(text, "your answer is too high"):
+			verdict.Outcome = TooHigh
+		case strings.Contains(text, "your answer is too low"):
+			verdict.Outcome = TooLow
+		default:
+			verdict.Outcome = Wrong
+		}
+	case strings.Contains(text, "You gave an answer too recently"):
+		verdict.Outcome = RateLimited
+		verdict.Wait = parseWait(text)
+	case strings.Contains(text, "Did you already complete it?"):
+		verdict.Outcome = AlreadySolved
+	}
+	return verdict
+}
+
+// Reads a wait time like "You have 1m 5s left to wait", or like "Please wait
+// 5 minutes before trying again", which grows with the wrong answers. Without
+// one, the wait is a minute, the time the website asks for after the first
+// wrong answers.
+func parseWait(text string) time.Duration {
+	if match := penaltyPattern.FindStringSubmatch(text); match != nil {
+		if match[1] == "one" {
+			return time.Minute
+		}
+		minutes, _ := strconv.Atoi(match[1])
+		return time.Duration(minutes) * time.Minute
+	}
+
+	match := waitPattern.FindStringSubmatch(text)
+	if match == nil || match[1] == "" && match[2] == "" {
+		return time.Minute
+	}
+
+	minutes, _ := strconv.Atoi(match[1])
+	seconds, _ := strconv.Atoi(match[2])
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+}
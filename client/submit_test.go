@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseVerdict(t *testing.T) {
+	testcases := []struct {
+		Name string
+		Page string
+		Want Outcome
+		Wait time.Duration
+	}{
+		{
+			Name: "correct",
+			Page: `<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer.</p></article></main>`,
+			Want: Correct,
+		},
+		{
+			Name: "too high",
+			Page: `<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again.</p></article>`,
+			Want: TooHigh,
+			Wait: time.Minute,
+		},
+		{
+			Name: "too low",
+			Page: `<article><p>That's not the right answer; your answer is too low.</p></article>`,
+			Want: TooLow,
+			Wait: time.Minute,
+		},
+		{
+			Name: "wrong after many wrong answers",
+			Page: `<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data. Because you have guessed incorrectly 6 times on this puzzle, please wait 5 minutes before trying again.</p></article>`,
+			Want: Wrong,
+			Wait: 5 * time.Minute,
+		},
+		{
+			Name: "wrong without hint",
+			Page: `<article><p>That's not the right answer.  If you're stuck, there are some general tips on the <a href="/2024/about">about page</a>.</p></article>`,
+			Want: Wrong,
+			Wait: time.Minute,
+		},
+		{
+			Name: "rate limited in seconds",
+			Page: `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 39s left to wait. <a href="/2024/day/3">[Return to Day 3]</a></p></article>`,
+			Want: RateLimited,
+			Wait: 39 * time.Second,
+		},
+		{
+			Name: "rate limited in minutes",
+			Page: `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 2s left to wait.</p></article>`,
+			Want: RateLimited,
+			Wait: 4*time.Minute + 2*time.Second,
+		},
+		{
+			Name: "already solved",
+			Page: `<article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2024/day/3">[Return to Day 3]</a></p></article>`,
+			Want: AlreadySolved,
+		},
+		{
+			Name: "unknown page",
+			Page: `<html><body>Something else</body></html>`,
+			Want: Unknown,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got := ParseVerdict(testcase.Page)
+			if got.Outcome != testcase.Want {
+				t.Errorf("Got wrong outcome: got %v, want %v (message %q)", got.Outcome, testcase.Want, got.Message)
+			}
+			if got.Wait != testcase.Wait {
+				t.Errorf("Got wrong wait: got %v, want %v", got.Wait, testcase.Wait)
+			}
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	var level, answer string
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/3/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		level, answer = r.FormValue("level"), r.FormValue("answer")
+		w.Write([]byte(`<article><p>That's the right answer!</p></article>`))
+	})
+
+	got, err := c.Submit(context.Background(), 3, 2, "48")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got.Outcome != Correct {
+		t.Errorf("Got wrong outcome: got %v, want %v", got.Outcome, Correct)
+	}
+	if level != "2" || answer != "48" {
+		t.Errorf("Got wrong form: got level %q answer %q, want 2 and 48", level, answer)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	throttle := Throttle{Path: filepath.Join(t.TempDir(), "next-submit")}
+	now := time.Date(2024, 12, 3, 6, 0, 0, 0, time.UTC)
+
+	if got, err := throttle.Remaining(now); err != nil || got != 0 {
+		t.Errorf("Got wrong wait before any hold: got %v, %v, want 0", got, err)
+	}
+
+	if err := throttle.Hold(now.Add(time.Minute)); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got, err := throttle.Remaining(now.Add(20 * time.Second)); err != nil || got != 40*time.Second {
+		t.Errorf("Got wrong wait: got %v, %v, want 40s", got, err)
+	}
+	if got, err := throttle.Remaining(now.Add(2 * time.Minute)); err != nil || got != 0 {
+		t.Errorf("Got wrong wait after the hold: got %v, %v, want 0", got, err)
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+)
+
+// Throttle spaces out the submissions, even across separate runs of the
+// command, by keeping the earliest time of the next submission in a file.
+type Throttle struct {
+	Path string
+}
+
+// NewThrottle returns the throttle kept in the cache directory.
+func NewThrottle() (Throttle, error) {
+	dir, err := inputs.CacheDir()
+	if err != nil {
+		return Throttle{}, err
+	}
+	return Throttle{Path: filepath.Join(dir, "next-submit")}, nil
+}
+
+// Remaining returns how long to wait from now before the next submission.
+func (t Throttle) Remaining(now time.Time) (time.Duration, error) {
+	data, err := os.ReadFile(t.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	next, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("reading %s: %w", t.Path, err)
+	}
+	return max(next.Sub(now), 0), nil
+}
+
+// Hold blocks the submissions until the given time.
+func (t Throttle) Hold(until time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(t.Path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.Path, []byte(until.UTC().Format(time.RFC3339)+"\n"), 0o644)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+func animateCommand(args []string) error {
+	defaults := animate.DefaultOptions()
+	flags := flag.NewFlagSet("animate", flag.ContinueOnError)
+	dayNumber := flags.Int("day", 0, "day to animate")
+	inputPath := flags.String("input", "", `input file, or "-" for stdin (default: the cached input of the day)`)
+	format := flags.String("format", string(defaults.Format), "output format: gif, or png for a numbered image per frame")
+	dir := flags.String("out", defaults.Dir, "output directory")
+	cellSize := flags.Int("cell-size", defaults.CellSize, "width and height of a cell, in pixels")
+	fps := flags.Int("fps", defaults.FPS, "frames per second of the GIF")
+	paletteSpec := flags.String("palette", "", `colours of the cells, added to the default ones, like "#=808080,.=000000"`)
+	frameSpec := flags.String("frames", "", `steps to draw, like "100:200", "500:" or ":50" (default: all of them)`)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	day, found := registry.Get(*dayNumber)
+	if !found {
+		return fmt.Errorf("day %d has no solution", *dayNumber)
+	}
+	animator := animate.Get(day.Number)
+	if animator == nil {
+		return fmt.Errorf("day %d has no animation", day.Number)
+	}
+	frames, err := parseFrameRange(*frameSpec)
+	if err != nil {
+		return err
+	}
+	palette, err := animate.ParsePalette(*paletteSpec)
+	if err != nil {
+		return err
+	}
+
+	input, err := inputs.Load(day.Number, *inputPath)
+	if err != nil {
+		return err
+	}
+
+	exporter, err := animate.New(animate.Options{
+		Format:   animate.Format(*format),
+		Dir:      *dir,
+		Name:     fmt.Sprintf("day-%d", day.Number),
+		CellSize: *cellSize,
+		FPS:      *fps,
+		Palette:  mergePalettes(animate.DefaultPalette, palette),
+	})
+	if err != nil {
+		return err
+	}
+	if err := runAnimation(context.Background(), animator, input, frames, exporter); err != nil {
+		return err
+	}
+
+	paths, err := exporter.Close()
+	if err != nil {
+		return err
+	}
+	if len(paths) == 1 {
+		fmt.Printf("Day %d: wrote %d frames to %s\n", day.Number, exporter.Frames(), paths[0])
+	} else {
+		fmt.Printf("Day %d: wrote %d frames to %s\n", day.Number, exporter.Frames(), *dir)
+	}
+	return nil
+}
+
+func mergePalettes(base, overrides animate.Palette) animate.Palette {
+	palette := maps.Clone(base)
+	maps.Copy(palette, overrides)
+	return palette
+}
+
+// The steps to draw, both included. To is -1 when there is no end.
+type frameRange struct {
+	From, To int
+}
+
+func (r frameRange) Contains(index int) bool {
+	return index >= r.From && (r.To < 0 || index <= r.To)
+}
+
+func parseFrameRange(spec string) (frameRange, error) {
+	if spec == "" {
+		return frameRange{0, -1}, nil
+	}
+
+	fromText, toText, found := strings.Cut(spec, ":")
+	if !found {
+		return frameRange{}, fmt.Errorf("invalid frames %q, want a range like 100:200", spec)
+	}
+	r := frameRange{0, -1}
+	for _, bound := range []struct {
+		Text  string
+		Value *int
+	}{{fromText, &r.From}, {toText, &r.To}} {
+		if bound.Text == "" {
+			continue
+		}
+		value, err := strconv.Atoi(bound.Text)
+		if err != nil || value < 0 {
+			return frameRange{}, fmt.Errorf("invalid frames %q, the bounds should be step numbers", spec)
+		}
+		*bound.Value = value
+	}
+	if r.To >= 0 && r.From > r.To {
+		return frameRange{}, fmt.Errorf("invalid frames %q, the start is after the end", spec)
+	}
+	return r, nil
+}
+
+// Runs the animation of a day, passing the steps in the range to the
+// observer. The animation is stopped once past the end of the range.
+func runAnimation(ctx context.Context, animator animate.Animator, input string, frames frameRange, observer trace.Observer[grid.Grid[rune]]) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	err := animator(ctx, input, trace.ObserverFunc[grid.Grid[rune]](func(step trace.Step[grid.Grid[rune]]) {
+		if frames.Contains(step.Index) {
+			observer.Observe(step)
+		}
+		if frames.To >= 0 && step.Index >= frames.To {
+			cancel()
+		}
+	}))
+	if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+func TestParseFrameRange(t *testing.T) {
+	testcases := []struct {
+		Spec string
+		Want frameRange
+	}{
+		{"", frameRange{0, -1}},
+		{"100:200", frameRange{100, 200}},
+		{"500:", frameRange{500, -1}},
+		{":50", frameRange{0, 50}},
+		{"7:7", frameRange{7, 7}},
+	}
+
+	for _, testcase := range testcases {
+		got, err := parseFrameRange(testcase.Spec)
+		if err != nil {
+			t.Fatalf("Got unexpected error for %q: %v", testcase.Spec, err)
+		}
+		if got != testcase.Want {
+			t.Errorf("Got wrong range for %q: got %v, want %v", testcase.Spec, got, testcase.Want)
+		}
+	}
+}
+
+func TestParseFrameRangeErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"single step", "100", `invalid frames "100", want a range like 100:200`},
+		{"not a number", "a:10", `invalid frames "a:10", the bounds should be step numbers`},
+		{"negative", "-5:10", `invalid frames "-5:10", the bounds should be step numbers`},
+		{"reversed", "10:5", `invalid frames "10:5", the start is after the end`},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := parseFrameRange(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+// Steps forever, until the context is done.
+func endlessAnimation(ctx context.Context, input string, observer trace.Observer[grid.Grid[rune]]) error {
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		observer.Observe(trace.Step[grid.Grid[rune]]{Index: i})
+	}
+}
+
+func TestRunAnimationStopsAfterTheRange(t *testing.T) {
+	recorder := &trace.Recorder[grid.Grid[rune]]{}
+	err := runAnimation(context.Background(), endlessAnimation, "", frameRange{3, 5}, recorder)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	got := make([]int, 0)
+	for _, step := range recorder.Steps {
+		got = append(got, step.Index)
+	}
+	if !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Errorf("Got wrong steps: got %v, want [3 4 5]", got)
+	}
+}
+
+func TestRunAnimationErrors(t *testing.T) {
+	failing := func(ctx context.Context, input string, observer trace.Observer[grid.Grid[rune]]) error {
+		return errors.New("line 1: bad input")
+	}
+	err := runAnimation(context.Background(), failing, "", frameRange{0, -1}, &trace.Recorder[grid.Grid[rune]]{})
+	if err == nil || err.Error() != "line 1: bad input" {
+		t.Errorf("Got wrong error: got %v, want line 1: bad input", err)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/bench"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+)
+
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	daySpec := flags.String("day", "all", `days to measure: a day ("15"), a range ("1-10"), a list ("1,3,5") or "all"`)
+	part := flags.Int("part", 0, "part to measure (1 or 2), or 0 for both parts")
+	runs := flags.Int("runs", 10, "number of times each part is run")
+	format := flags.String("format", "table", `output format, "table" or "json"`)
+	from := flags.String("from", "", `read the output of "go test -bench" from this file, or "-" for stdin, instead of running the solvers`)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	write, err := benchWriter(*format)
+	if err != nil {
+		return err
+	}
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("invalid part %d, it should be 1, 2 or 0 for both", *part)
+	}
+
+	var results []bench.Result
+	if *from != "" {
+		results, err = readGoTestResults(*from)
+	} else {
+		results, err = measureDays(*daySpec, *part, *runs)
+	}
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, results)
+}
+
+func benchWriter(format string) (func(io.Writer, []bench.Result) error, error) {
+	switch format {
+	case "table":
+		return bench.WriteTable, nil
+	case "json":
+		return bench.WriteJSON, nil
+	}
+	return nil, fmt.Errorf("invalid format %q, it should be table or json", format)
+}
+
+func measureDays(daySpec string, part, runs int) ([]bench.Result, error) {
+	days, err := selectDays(daySpec)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]bench.Result, 0, 2*len(days))
+	for _, day := range days {
+		input, err := inputs.Load(day.Number, "")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range selectParts(part) {
+			solver := day.Part(p)
+			if solver == nil {
+				continue
+			}
+			result, err := bench.Measure(solver, input, runs)
+			if err != nil {
+				return nil, fmt.Errorf("day %d, part %d: %w", day.Number, p, err)
+			}
+			result.Day, result.Part = day.Number, p
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func readGoTestResults(path string) ([]bench.Result, error) {
+	if path == "-" {
+		return bench.ParseGoTest(os.Stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return bench.ParseGoTest(file)
+}
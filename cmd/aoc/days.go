@@ -0,0 +1,24 @@
+package main
+
+// Every day registers its solvers when its package is imported.
+import (
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-1"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-10"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-11"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-12"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-13"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-14"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-15"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-18"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-19"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-2"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-22"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-23"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-3"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-4"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-5"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-6"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-7"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-8"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-9"
+)
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/examples"
+)
+
+func examplesCommand(args []string) error {
+	flags := flag.NewFlagSet("examples", flag.ContinueOnError)
+	dayNumber := flags.Int("day", 0, "day of the puzzle")
+	htmlPath := flags.String("html", "", "saved HTML page of the puzzle description")
+	dir := flags.String("dir", "", "directory of the fixtures (default: day-N/testdata)")
+	force := flags.Bool("force", false, "replace existing fixtures")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *dayNumber < 1 || *dayNumber > 25 {
+		return errors.New("--day is required, and should be a number from 1 to 25")
+	}
+	if *htmlPath == "" {
+		return errors.New("--html is required")
+	}
+	if *dir == "" {
+		*dir = filepath.Join(fmt.Sprintf("day-%d", *dayNumber), "testdata")
+	}
+
+	fixturesPath := filepath.Join(*dir, examples.FixturesFile)
+	if _, err := os.Stat(fixturesPath); err == nil && !*force {
+		return fmt.Errorf("%s already exists, use --force to replace it", fixturesPath)
+	}
+
+	page, err := os.ReadFile(*htmlPath)
+	if err != nil {
+		return err
+	}
+	puzzle, err := examples.Extract(string(page))
+	if err != nil {
+		return fmt.Errorf("%s: %w", *htmlPath, err)
+	}
+	if err := examples.Write(*dir, puzzle); err != nil {
+		return err
+	}
+
+	for i, part := range puzzle.Parts {
+		fmt.Printf("Part %d: %s, answer %s\n", i+1, examples.ExampleFile(part.Example), part.Answer)
+	}
+	fmt.Printf("Saved %d examples to %s\n", len(puzzle.Examples), *dir)
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/client"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+)
+
+func fetchCommand(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	daySpec := flags.String("day", "", `days to fetch: a day ("15"), a range ("1-10") or a list ("1,3,5")`)
+	baseURL := flags.String("base-url", "", "address of the website (default: $AOC_BASE_URL or "+client.DefaultBaseURL+")")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *daySpec == "" {
+		return errors.New("--day is required")
+	}
+	days, err := parseDaySpec(*daySpec)
+	if err != nil {
+		return err
+	}
+
+	// The session is only needed, and so only required, for the inputs
+	// missing from the cache.
+	missing := make([]int, 0)
+	for _, day := range days {
+		path, cached, err := cachedInput(day)
+		if err != nil {
+			return err
+		}
+		if cached {
+			fmt.Printf("Day %d: already in %s\n", day, path)
+		} else {
+			missing = append(missing, day)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	session, err := client.LoadSession()
+	if err != nil {
+		return err
+	}
+	c := client.New(session)
+	if *baseURL != "" {
+		c.BaseURL = *baseURL
+	}
+
+	for _, day := range missing {
+		path, _, err := fetchInput(context.Background(), c, day)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Day %d: saved to %s\n", day, path)
+	}
+	return nil
+}
+
+// Returns the path of the input of a day in the cache, and whether it is
+// there.
+func cachedInput(day int) (path string, found bool, err error) {
+	path, err = inputs.CachePath(day)
+	if err != nil {
+		return "", false, err
+	}
+	_, err = os.Stat(path)
+	return path, err == nil, nil
+}
+
+// Downloads the input of a day into the cache, unless it is already there.
+// An input never changes, so it is not fetched again.
+func fetchInput(ctx context.Context, c *client.Client, day int) (path string, fetched bool, err error) {
+	path, cached, err := cachedInput(day)
+	if err != nil || cached {
+		return path, false, err
+	}
+
+	input, err := c.FetchInput(ctx, day)
+	if err != nil {
+		return "", false, err
+	}
+	if err := inputs.Save(day, input); err != nil {
+		return "", false, err
+	}
+	return path, true, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/client"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+)
+
+func TestFetchInputIsCached(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("125 17\n"))
+	}))
+	defer server.Close()
+	c := &client.Client{BaseURL: server.URL, Session: "secret", HTTPClient: server.Client()}
+
+	for i, wantFetched := range []bool{true, false} {
+		_, fetched, err := fetchInput(context.Background(), c, 11)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		if fetched != wantFetched {
+			t.Errorf("Got wrong fetched for call %d: got %v, want %v", i+1, fetched, wantFetched)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Got wrong number of requests: got %d, want 1", requests)
+	}
+
+	got, err := inputs.Load(11, "")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got != "125 17\n" {
+		t.Errorf("Got wrong input: got %q, want %q", got, "125 17\n")
+	}
+}
+
+func TestFetchInputNotUnlocked(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	c := &client.Client{BaseURL: server.URL, Session: "secret", HTTPClient: server.Client()}
+
+	if _, _, err := fetchInput(context.Background(), c, 25); err == nil {
+		t.Fatalf("Got no error for a locked day")
+	}
+	if _, err := inputs.Load(25, ""); err == nil {
+		t.Errorf("Got an input saved for a locked day")
+	}
+}
+
+func TestFetchCommandNeedsSessionOnlyForMissingInputs(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	t.Setenv("AOC_SESSION", "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := inputs.Save(1, "3 4\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fetchCommand([]string{"--day", "1"}); err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+	if err := fetchCommand([]string{"--day", "1-2"}); !errors.Is(err, client.ErrNoSession) {
+		t.Errorf("Got wrong error: got %v, want %v", err, client.ErrNoSession)
+	}
+}
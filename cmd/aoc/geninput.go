@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+func genInputCommand(args []string) error {
+	flags := flag.NewFlagSet("gen-input", flag.ContinueOnError)
+	daySpec := flags.String("day", "", `days to generate: a day ("15"), a range ("1-10"), a list ("1,3,5") or "all"`)
+	seed := flags.Uint64("seed", 1, "seed of the random inputs, the same seed giving the same inputs")
+	out := flags.String("out", "", "file the input is written to (default: stdout)")
+	cache := flags.Bool("cache", false, "save the inputs to the cache, for run, bench and the benchmarks")
+	force := flags.Bool("force", false, "replace the inputs already in the cache, fetched or generated")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *daySpec == "" {
+		return errors.New("--day is required")
+	}
+	days, err := selectDays(*daySpec)
+	if err != nil {
+		return err
+	}
+	if *cache && *out != "" {
+		return errors.New("--out cannot be used with --cache")
+	}
+	if !*cache && len(days) != 1 {
+		return errors.New("several days can only be generated with --cache")
+	}
+
+	if !*cache {
+		input, err := generateInput(days[0], *seed)
+		if err != nil {
+			return err
+		}
+		if *out == "" {
+			_, err = os.Stdout.WriteString(input)
+			return err
+		}
+		return os.WriteFile(*out, []byte(input), 0o644)
+	}
+
+	for _, day := range days {
+		if day.Generate == nil {
+			fmt.Printf("Day %d: no generator\n", day.Number)
+			continue
+		}
+		path, err := inputs.CachePath(day.Number)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err == nil && !*force {
+			fmt.Printf("Day %d: already in %s\n", day.Number, path)
+			continue
+		}
+
+		input, err := generateInput(day, *seed)
+		if err != nil {
+			return err
+		}
+		if err := inputs.Save(day.Number, input); err != nil {
+			return err
+		}
+		fmt.Printf("Day %d: saved to %s\n", day.Number, path)
+	}
+	return nil
+}
+
+// Generates the input of a day. Every day draws its own numbers from the
+// seed, so that the input of a day does not depend on the other days.
+func generateInput(day registry.Day, seed uint64) (string, error) {
+	if day.Generate == nil {
+		return "", fmt.Errorf("day %d has no input generator", day.Number)
+	}
+	return day.Generate(rand.New(rand.NewPCG(seed, uint64(day.Number)))), nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+func TestGenerateInputOfEveryDay(t *testing.T) {
+	for _, day := range registry.All() {
+		t.Run(fmt.Sprintf("day-%02d", day.Number), func(t *testing.T) {
+			input, err := generateInput(day, 1)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if again, _ := generateInput(day, 1); again != input {
+				t.Errorf("Got a different input for the same seed")
+			}
+			if other, _ := generateInput(day, 2); other == input {
+				t.Errorf("Got the same input for another seed")
+			}
+
+			if _, err := day.Part1(context.Background(), input); err != nil {
+				t.Errorf("Got unexpected error from part 1: %v", err)
+			}
+		})
+	}
+}
+
+func TestGenInputCommandCache(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	if err := inputs.Save(2, "fetched\n"); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if err := genInputCommand([]string{"--day", "1-3", "--cache", "--seed", "5"}); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	for day, fetched := range map[int]bool{1: false, 2: true, 3: false} {
+		input, err := inputs.Load(day, "")
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		if (input == "fetched\n") != fetched {
+			t.Errorf("Got wrong input for day %d: %.20q", day, input)
+		}
+	}
+}
+
+func TestGenInputCommandOut(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := genInputCommand([]string{"--day", "22", "--seed", "3", "--out", path}); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	day, _ := registry.Get(22)
+	want, _ := generateInput(day, 3)
+	if got, err := os.ReadFile(path); err != nil || string(got) != want {
+		t.Errorf("Got wrong input: %v", err)
+	}
+}
+
+func TestGenInputCommandErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Args    []string
+		WantErr string
+	}{
+		{"no day", nil, "--day is required"},
+		{"several days to stdout", []string{"--day", "1-2"}, "several days can only be generated with --cache"},
+		{"out and cache", []string{"--day", "1", "--cache", "--out", "x"}, "--out cannot be used with --cache"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			err := genInputCommand(testcase.Args)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	day1 "github.com/tejesh-kaliki/advent-of-code-2024/day-1"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+)
+
+func listsCommand(args []string) error {
+	flags := flag.NewFlagSet("lists", flag.ContinueOnError)
+	inputPath := flags.String("input", "", `lists to compare, or "-" for stdin (default: the cached input of day 1)`)
+	metricName := flags.String("metric", "abs", `distance between two locations: "abs", "squared" or "bounded:N"`)
+	columnsText := flags.String("columns", "", `two columns to compare, like "1,3" (default: every pair, as a report)`)
+	maxInMemory := flags.Int("max-in-memory", day1.DefaultSortOptions.MaxInMemory, "most locations of a column sorted in memory, the others being sorted on disk")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	metric, err := day1.ParseMetric(*metricName)
+	if err != nil {
+		return err
+	}
+	columns, err := parseColumns(*columnsText)
+	if err != nil {
+		return err
+	}
+
+	r, err := openLists(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	sortOpts := day1.SortOptions{MaxInMemory: *maxInMemory}
+	if columns != nil {
+		distance, err := day1.TotalDistance(r, day1.DistanceOptions{Columns: columns, Metric: metric, Sort: sortOpts})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Distance between columns %d and %d: %d\n", columns[0]+1, columns[1]+1, distance)
+		return nil
+	}
+
+	comparison, err := day1.Compare(r, metric, sortOpts)
+	if err != nil {
+		return err
+	}
+	return comparison.Write(os.Stdout)
+}
+
+// Parses two columns counted from 1, like "1,3", into columns counted from 0.
+func parseColumns(text string) ([]int, error) {
+	if text == "" {
+		return nil, nil
+	}
+	first, second, found := strings.Cut(text, ",")
+	if !found {
+		return nil, fmt.Errorf("invalid columns %q, want two columns like 1,2", text)
+	}
+
+	columns := make([]int, 0, 2)
+	for _, columnText := range []string{first, second} {
+		column, err := strconv.Atoi(strings.TrimSpace(columnText))
+		if err != nil || column < 1 {
+			return nil, fmt.Errorf("invalid column %q, want a number from 1", columnText)
+		}
+		columns = append(columns, column-1)
+	}
+	return columns, nil
+}
+
+// Opens the lists without reading them, so that they are streamed.
+func openLists(path string) (io.ReadCloser, error) {
+	switch path {
+	case "-":
+		return io.NopCloser(os.Stdin), nil
+	case "":
+		cachePath, err := inputs.CachePath(1)
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(cachePath)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("day 1: %w, save it at %s or pass its path with --input", inputs.ErrMissing, cachePath)
+		}
+		return f, err
+	}
+	return os.Open(path)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseColumns(t *testing.T) {
+	testcases := []struct {
+		Name  string
+		Input string
+		Want  []int
+	}{
+		{"every pair", "", nil},
+		{"two columns", "1,3", []int{0, 2}},
+		{"spaces", "2, 1", []int{1, 0}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got, err := parseColumns(testcase.Input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if !slices.Equal(got, testcase.Want) {
+				t.Errorf("Got wrong columns: got %v, want %v", got, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestParseColumnsErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"single column", "1", `invalid columns "1", want two columns like 1,2`},
+		{"not a number", "1,b", `invalid column "b", want a number from 1`},
+		{"counted from 0", "0,1", `invalid column "0", want a number from 1`},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := parseColumns(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Command aoc runs the solutions of all the days from a single binary.
+//
+// Usage:
+//
+//	aoc <command> [flags]
+//
+// Run "aoc help" to list the available commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+type command struct {
+	Name  string
+	Short string
+	Run   func(args []string) error
+}
+
+var commands = []command{
+	{"animate", "draw the simulation of a day as a GIF or PNG images", animateCommand},
+	{"bench", "measure the time and allocations of the solvers", benchCommand},
+	{"examples", "extract the examples of a saved puzzle page into test fixtures", examplesCommand},
+	{"fetch", "download the puzzle inputs into the cache", fetchCommand},
+	{"gen-input", "generate random inputs of the size of the real ones", genInputCommand},
+	{"lists", "compare the location lists of day 1 column by column", listsCommand},
+	{"new-day", "create the skeleton of a new day", newDayCommand},
+	{"play", "play the simulation of a day in the terminal", playCommand},
+	{"reports", "explain which reports of day 2 are safe and why", reportsCommand},
+	{"run", "run the solvers of one or more days", runCommand},
+	{"submit", "submit the answer of a part to the website", submitCommand},
+	{"verify", "check the solvers against the recorded answers", verifyCommand},
+	{"watch", "run the tests of the changed packages on every change", watchCommand},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.Name, cmd.Short)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, `Run "aoc <command> -h" for the flags of a command.`)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name, args := os.Args[1], os.Args[2:]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+		return
+	}
+
+	for _, cmd := range commands {
+		if cmd.Name != name {
+			continue
+		}
+
+		err := cmd.Run(args)
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "aoc "+name+":", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var dayTemplates = template.Must(template.ParseFS(templateFiles, "templates/*.tmpl"))
+
+const modulePath = "github.com/tejesh-kaliki/advent-of-code-2024"
+
+func newDayCommand(args []string) error {
+	flags := flag.NewFlagSet("new-day", flag.ContinueOnError)
+	dayNumber := flags.Int("day", 0, "day to create")
+	root := flags.String("root", ".", "root directory of the repository")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *dayNumber < 1 || *dayNumber > 25 {
+		return errors.New("--day is required, and should be a number from 1 to 25")
+	}
+	if _, err := os.Stat(filepath.Join(*root, "go.mod")); err != nil {
+		return fmt.Errorf("%s is not the root of the repository: %w", *root, err)
+	}
+
+	if err := createDay(*root, *dayNumber); err != nil {
+		return err
+	}
+	fmt.Printf("Created day-%d, run its tests with: go test ./day-%d\n", *dayNumber, *dayNumber)
+	return nil
+}
+
+// Writes the skeleton of a day in root, and registers it with the runner.
+// An existing day is never overwritten.
+func createDay(root string, day int) error {
+	dir := filepath.Join(root, fmt.Sprintf("day-%d", day))
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%s already exists", dir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	files := make(map[string][]byte)
+	for _, name := range []string{"main.go", "main_test.go"} {
+		source, err := renderDayTemplate(name+".tmpl", day)
+		if err != nil {
+			return err
+		}
+		files[name] = source
+	}
+
+	daysPath := filepath.Join(root, "cmd", "aoc", "days.go")
+	days, err := os.ReadFile(daysPath)
+	if err != nil {
+		return err
+	}
+	days, err = addDayImport(days, day)
+	if err != nil {
+		return fmt.Errorf("registering the day in %s: %w", daysPath, err)
+	}
+
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return err
+	}
+	for name, source := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), source, 0o644); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(daysPath, days, 0o644)
+}
+
+func renderDayTemplate(name string, day int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := dayTemplates.ExecuteTemplate(&buf, name, struct{ Number int }{day}); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// Adds the blank import of a day to the source of days.go. The imports are
+// sorted again by gofmt.
+func addDayImport(source []byte, day int) ([]byte, error) {
+	importLine := fmt.Sprintf("\t_ %q\n", fmt.Sprintf("%s/day-%d", modulePath, day))
+	if bytes.Contains(source, []byte(importLine)) {
+		return nil, fmt.Errorf("day %d is already imported", day)
+	}
+
+	text := string(source)
+	end := strings.LastIndex(text, ")")
+	if !strings.Contains(text, "import (") || end < 0 {
+		return nil, errors.New("no import block found")
+	}
+	return format.Source([]byte(text[:end] + importLine + text[end:]))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDaysSource = `package main
+
+// Every day registers its solvers when its package is imported.
+import (
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-1"
+	_ "github.com/tejesh-kaliki/advent-of-code-2024/day-3"
+)
+`
+
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "cmd", "aoc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "cmd", "aoc", "days.go"), []byte(testDaysSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestCreateDay(t *testing.T) {
+	root := newTestRepo(t)
+
+	if err := createDay(root, 2); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"main.go", "main_test.go"} {
+		source, err := os.ReadFile(filepath.Join(root, "day-2", name))
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(string(source), "package day2\n") {
+			t.Errorf("Got wrong package in %s:\n%s", name, source)
+		}
+	}
+
+	days, err := os.ReadFile(filepath.Join(root, "cmd", "aoc", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(testDaysSource, "day-1\"\n", "day-1\"\n\t_ \"github.com/tejesh-kaliki/advent-of-code-2024/day-2\"\n", 1)
+	if string(days) != want {
+		t.Errorf("Got wrong days.go:\n%s\nwant:\n%s", days, want)
+	}
+}
+
+func TestCreateDayRefusesExistingDay(t *testing.T) {
+	root := newTestRepo(t)
+	mainPath := filepath.Join(root, "day-3", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mainPath, []byte("package day3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := createDay(root, 3); err == nil {
+		t.Fatalf("Got no error for an existing day")
+	}
+
+	source, err := os.ReadFile(mainPath)
+	if err != nil || string(source) != "package day3\n" {
+		t.Errorf("Existing day was changed: %q, %v", source, err)
+	}
+	days, err := os.ReadFile(filepath.Join(root, "cmd", "aoc", "days.go"))
+	if err != nil || string(days) != testDaysSource {
+		t.Errorf("days.go was changed:\n%s", days)
+	}
+}
+
+func TestAddDayImportRefusesDuplicate(t *testing.T) {
+	if _, err := addDayImport([]byte(testDaysSource), 3); err == nil {
+		t.Errorf("Got no error for a day already imported")
+	}
+}
+
+// Builds and vets a generated day in a module of its own, using the packages
+// of this module, so that the templates keep up with the packages they use.
+func TestCreatedDayBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a generated day")
+	}
+	gomod, err := exec.Command("go", "env", "GOMOD").Output()
+	if err != nil {
+		t.Skipf("go is not available: %v", err)
+	}
+	moduleRoot := filepath.Dir(strings.TrimSpace(string(gomod)))
+
+	root := newTestRepo(t)
+	if err := createDay(root, 16); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	// The generated tests import the internal packages of the repository, so
+	// the day is built in a module nested in its path.
+	goMod := fmt.Sprintf(`module github.com/tejesh-kaliki/advent-of-code-2024/generated
+
+go 1.23
+
+require github.com/tejesh-kaliki/advent-of-code-2024 v0.0.0
+
+replace github.com/tejesh-kaliki/advent-of-code-2024 => %s
+`, moduleRoot)
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{{"build", "./day-16"}, {"vet", "./day-16"}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("go %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/terminal"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+func playCommand(args []string) error {
+	flags := flag.NewFlagSet("play", flag.ContinueOnError)
+	dayNumber := flags.Int("day", 0, "day to play")
+	inputPath := flags.String("input", "", `input file, or "-" for stdin (default: the cached input of the day)`)
+	fps := flags.Int("fps", 10, "steps per second")
+	paletteSpec := flags.String("palette", "", `colours of the cells, added to the default ones, like "#=808080,.=000000"`)
+	frameSpec := flags.String("frames", "", `steps to play, like "100:200", "500:" or ":50" (default: all of them)`)
+	maxSteps := flags.Int("max-steps", 1000, "most steps kept in memory to play when --frames has no end, 0 for no limit")
+	static := flags.Bool("static", false, "only print the last step, as done when the output is not a terminal")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	day, found := registry.Get(*dayNumber)
+	if !found {
+		return fmt.Errorf("day %d has no solution", *dayNumber)
+	}
+	animator := animate.Get(day.Number)
+	if animator == nil {
+		return fmt.Errorf("day %d has no animation", day.Number)
+	}
+	if *fps < 1 {
+		return fmt.Errorf("invalid speed %d, want at least 1 step per second", *fps)
+	}
+	if *maxSteps < 0 {
+		return fmt.Errorf("invalid number of steps %d, want 0 or more", *maxSteps)
+	}
+	frames, err := parseFrameRange(*frameSpec)
+	if err != nil {
+		return err
+	}
+	palette, err := animate.ParsePalette(*paletteSpec)
+	if err != nil {
+		return err
+	}
+	if *inputPath == "-" && !*static {
+		return errors.New("the input cannot be read from stdin, which is used for the keys, unless --static is set")
+	}
+
+	input, err := inputs.Load(day.Number, *inputPath)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	renderer := terminal.Renderer{
+		Palette: mergePalettes(animate.DefaultPalette, palette),
+		Mode:    terminal.DetectColorMode(os.Stdout),
+	}
+	if *static || !terminal.IsTerminal(os.Stdout) {
+		// Only the last step is kept, however long the simulation.
+		var last *trace.Step[grid.Grid[rune]]
+		err := runAnimation(ctx, animator, input, frames, trace.ObserverFunc[grid.Grid[rune]](func(step trace.Step[grid.Grid[rune]]) {
+			last = &step
+		}))
+		if err != nil {
+			return err
+		}
+		if last == nil {
+			return fmt.Errorf("day %d has no steps in %q", day.Number, *frameSpec)
+		}
+		return writeStep(os.Stdout, renderer, *last)
+	}
+
+	// Every step played is kept for seeking, so a long simulation like day 14
+	// is cut after the first steps of the range.
+	recorder := &trace.Recorder[grid.Grid[rune]]{}
+	if err := runAnimation(ctx, animator, input, limitFrames(frames, *maxSteps), recorder); err != nil {
+		return err
+	}
+	if len(recorder.Steps) == 0 {
+		return fmt.Errorf("day %d has no steps in %q", day.Number, *frameSpec)
+	}
+
+	var keys <-chan string
+	if terminal.IsTerminal(os.Stdin) {
+		restore, err := terminal.MakeRaw(os.Stdin)
+		if err != nil {
+			return err
+		}
+		defer restore()
+		keys = terminal.ReadKeys(os.Stdin)
+	}
+
+	player := &terminal.Player{Steps: recorder.Steps, Renderer: renderer, FPS: *fps}
+	return player.Play(ctx, keys, os.Stdout)
+}
+
+// Ends a range without an end after the given number of steps, unless it is 0.
+func limitFrames(frames frameRange, maxSteps int) frameRange {
+	if frames.To < 0 && maxSteps > 0 {
+		frames.To = frames.From + maxSteps - 1
+	}
+	return frames
+}
+
+// Writes a step, with its index and action, followed by its grid.
+func writeStep(w io.Writer, renderer terminal.Renderer, step trace.Step[grid.Grid[rune]]) error {
+	if step.Action != "" {
+		fmt.Fprintf(w, "Step %d: %s\n", step.Index, step.Action)
+	} else {
+		fmt.Fprintf(w, "Step %d\n", step.Index)
+	}
+	return renderer.Render(w, step.State)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/terminal"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+func TestWriteStep(t *testing.T) {
+	cells, err := grid.ParseRunes("#.\n.#")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	step := trace.Step[grid.Grid[rune]]{Index: 7, Action: "move left", State: cells}
+	if err := writeStep(&buf, terminal.Renderer{Mode: terminal.NoColor}, step); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if want := "Step 7: move left\n#.\n.#\n"; buf.String() != want {
+		t.Errorf("Got wrong output: got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestLimitFrames(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Input    frameRange
+		MaxSteps int
+		Want     frameRange
+	}{
+		{"range without an end is cut", frameRange{0, -1}, 1000, frameRange{0, 999}},
+		{"range from a step is cut after it", frameRange{500, -1}, 100, frameRange{500, 599}},
+		{"range with an end is kept", frameRange{0, 5000}, 1000, frameRange{0, 5000}},
+		{"no limit", frameRange{0, -1}, 0, frameRange{0, -1}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if got := limitFrames(testcase.Input, testcase.MaxSteps); got != testcase.Want {
+				t.Errorf("Got wrong frames: got %v, want %v", got, testcase.Want)
+			}
+		})
+	}
+}
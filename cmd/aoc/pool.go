@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/parallel"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+type partStatus string
+
+const (
+	statusOK      partStatus = "ok"
+	statusTimeout partStatus = "timeout"
+	statusPanic   partStatus = "panic"
+	statusError   partStatus = "error"
+)
+
+type partResult struct {
+	Day, Part int
+	Status    partStatus
+	Answer    any
+	Err       error
+	Elapsed   time.Duration
+}
+
+// A part to run, with the input of its day. Days without input are still
+// run, so that the missing input shows up as an error in the results.
+type partJob struct {
+	Day, Part int
+	Solver    registry.Solver
+	Input     string
+	InputErr  error
+}
+
+// Runs a solver, cancelling its context after the timeout, if not 0. A panic
+// of the solver, or of the goroutines it runs with the parallel package, is
+// turned into a result. The long-running solvers check their context and
+// return soon after their time is up. A solver ignoring it is left running in
+// the background.
+func runPart(ctx context.Context, solver registry.Solver, input string, timeout time.Duration) partResult {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan partResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- partResult{Status: statusPanic, Err: &parallel.PanicError{Value: r, Stack: debug.Stack()}}
+			}
+		}()
+
+		answer, err := solver(ctx, input)
+		var panicErr *parallel.PanicError
+		switch {
+		case errors.As(err, &panicErr):
+			done <- partResult{Status: statusPanic, Err: err}
+		case errors.Is(err, context.DeadlineExceeded):
+			done <- partResult{Status: statusTimeout, Err: fmt.Errorf("timed out after %v", timeout)}
+		case err != nil:
+			done <- partResult{Status: statusError, Err: err}
+		default:
+			done <- partResult{Status: statusOK, Answer: answer}
+		}
+	}()
+
+	var result partResult
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result = partResult{Status: statusError, Err: ctx.Err()}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			result = partResult{Status: statusTimeout, Err: fmt.Errorf("timed out after %v", timeout)}
+		}
+	}
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// Runs the jobs on the given number of workers, and returns their results in
+// the order of the jobs.
+func runPool(ctx context.Context, jobs []partJob, workers int, timeout time.Duration) []partResult {
+	results := make([]partResult, len(jobs))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				job := jobs[i]
+				if job.InputErr != nil {
+					results[i] = partResult{Status: statusError, Err: job.InputErr}
+				} else {
+					results[i] = runPart(ctx, job.Solver, job.Input, timeout)
+				}
+				results[i].Day, results[i].Part = job.Day, job.Part
+			}
+		}()
+	}
+
+	for i := range jobs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return results
+}
+
+// Writes the results as a table, with the answer of the parts that finished
+// and the error of the others.
+func writeResults(w io.Writer, results []partResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Day\tPart\tStatus\tTime\tAnswer")
+	for _, result := range results {
+		answer := fmt.Sprint(result.Answer)
+		if result.Status != statusOK {
+			answer = result.Err.Error()
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%v\t%s\n", result.Day, result.Part, result.Status, result.Elapsed.Round(time.Millisecond), answer)
+	}
+	return tw.Flush()
+}
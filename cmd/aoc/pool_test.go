@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/parallel"
+)
+
+func answerSolver(answer any) func(context.Context, string) (any, error) {
+	return func(ctx context.Context, input string) (any, error) { return answer, nil }
+}
+
+func TestRunPart(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Solver  func(context.Context, string) (any, error)
+		Status  partStatus
+		WantErr string
+	}{
+		{
+			Name:   "solver returns an answer",
+			Solver: answerSolver(42),
+			Status: statusOK,
+		},
+		{
+			Name: "solver returns an error",
+			Solver: func(ctx context.Context, input string) (any, error) {
+				return nil, errors.New("line 1: bad input")
+			},
+			Status:  statusError,
+			WantErr: "line 1: bad input",
+		},
+		{
+			Name: "solver panics",
+			Solver: func(ctx context.Context, input string) (any, error) {
+				panic("Parallel lines")
+			},
+			Status:  statusPanic,
+			WantErr: "panic: Parallel lines",
+		},
+		{
+			Name: "goroutine of the solver panics",
+			Solver: func(ctx context.Context, input string) (any, error) {
+				return parallel.Map(ctx, []int{1, 0}, func(ctx context.Context, n int) (int, error) {
+					return 1 / n, nil
+				})
+			},
+			Status:  statusPanic,
+			WantErr: "panic: runtime error: integer divide by zero",
+		},
+		{
+			Name: "solver stops when cancelled",
+			Solver: func(ctx context.Context, input string) (any, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			Status:  statusTimeout,
+			WantErr: "timed out after 20ms",
+		},
+		{
+			Name: "solver ignores cancellation",
+			Solver: func(ctx context.Context, input string) (any, error) {
+				time.Sleep(time.Second)
+				return 1, nil
+			},
+			Status:  statusTimeout,
+			WantErr: "timed out after 20ms",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got := runPart(context.Background(), testcase.Solver, "", 20*time.Millisecond)
+			if got.Status != testcase.Status {
+				t.Errorf("Got wrong status: got %s, want %s (error %v)", got.Status, testcase.Status, got.Err)
+			}
+			if testcase.WantErr != "" && (got.Err == nil || got.Err.Error() != testcase.WantErr) {
+				t.Errorf("Got wrong error: got %v, want %s", got.Err, testcase.WantErr)
+			}
+			if got.Elapsed > 500*time.Millisecond {
+				t.Errorf("Waited too long for the solver: %v", got.Elapsed)
+			}
+		})
+	}
+}
+
+func TestRunPool(t *testing.T) {
+	panicking := func(ctx context.Context, input string) (any, error) { panic("Parallel lines") }
+	echo := func(ctx context.Context, input string) (any, error) { return input, nil }
+	jobs := []partJob{
+		{Day: 1, Part: 1, Solver: echo, Input: "one"},
+		{Day: 13, Part: 1, Solver: panicking},
+		{Day: 13, Part: 2, Solver: answerSolver(7)},
+		{Day: 22, Part: 1, Solver: echo, InputErr: errors.New("puzzle input not found")},
+		{Day: 23, Part: 2, Solver: echo, Input: "two"},
+	}
+
+	results := runPool(context.Background(), jobs, 2, time.Second)
+
+	want := []struct {
+		Day, Part int
+		Status    partStatus
+		Answer    any
+	}{
+		{1, 1, statusOK, "one"},
+		{13, 1, statusPanic, nil},
+		{13, 2, statusOK, 7},
+		{22, 1, statusError, nil},
+		{23, 2, statusOK, "two"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Got wrong number of results: got %d, want %d", len(results), len(want))
+	}
+	for i, result := range results {
+		if result.Day != want[i].Day || result.Part != want[i].Part || result.Status != want[i].Status || result.Answer != want[i].Answer {
+			t.Errorf("Got wrong result %d: got %+v, want %+v", i, result, want[i])
+		}
+	}
+
+	var table bytes.Buffer
+	if err := writeResults(&table, results); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if !strings.Contains(table.String(), "panic: Parallel lines") {
+		t.Errorf("Table does not show the panic:\n%s", table.String())
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+)
+
+// What to record while running a part, for the profiling flags of run.
+type profileOptions struct {
+	Dir   string
+	CPU   bool
+	Mem   bool
+	Trace bool
+	// Number of hot functions printed for each profile, or 0 to only write the
+	// files.
+	Top int
+}
+
+func (opts profileOptions) enabled() bool {
+	return opts.CPU || opts.Mem || opts.Trace
+}
+
+// A profile written for a part.
+type profileFile struct {
+	Kind string
+	Path string
+}
+
+// Runs a part while recording the profiles of the options, written to the
+// directory of the options as day-DD-part-P.cpu.pprof, .mem.pprof and .trace.
+func profilePart(opts profileOptions, day, part int, run func()) (files []profileFile, err error) {
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	path := func(ext string) string {
+		return filepath.Join(opts.Dir, fmt.Sprintf("day-%02d-part-%d.%s", day, part, ext))
+	}
+
+	if opts.Mem {
+		// Sample an allocation every 4 KB rather than every 512 KB, so that
+		// the small allocations of the solvers show up too.
+		runtime.MemProfileRate = 4096
+	}
+
+	// Closes a file, keeping the first error.
+	closeFile := func(f *os.File) {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	if opts.CPU {
+		f, err := os.Create(path("cpu.pprof"))
+		if err != nil {
+			return nil, err
+		}
+		defer closeFile(f)
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return nil, err
+		}
+		defer pprof.StopCPUProfile()
+		files = append(files, profileFile{"cpu", f.Name()})
+	}
+
+	if opts.Trace {
+		f, err := os.Create(path("trace"))
+		if err != nil {
+			return nil, err
+		}
+		defer closeFile(f)
+		if err := trace.Start(f); err != nil {
+			return nil, err
+		}
+		defer trace.Stop()
+		files = append(files, profileFile{"trace", f.Name()})
+	}
+
+	run()
+
+	if opts.Mem {
+		f, err := os.Create(path("mem.pprof"))
+		if err != nil {
+			return nil, err
+		}
+		defer closeFile(f)
+		// Collect the garbage so that the profile is up to date.
+		runtime.GC()
+		if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+			return nil, err
+		}
+		files = append(files, profileFile{"mem", f.Name()})
+	}
+	return files, nil
+}
+
+// Writes the n functions taking the most time, or allocating the most memory,
+// in a profile, using go tool pprof. Traces have no such report.
+func writeTop(w io.Writer, file profileFile, n int) error {
+	args := []string{"tool", "pprof", "-top", "-nodecount=" + strconv.Itoa(n)}
+	switch file.Kind {
+	case "cpu":
+	case "mem":
+		args = append(args, "-sample_index=alloc_space")
+	default:
+		return nil
+	}
+
+	cmd := exec.Command("go", append(args, file.Path)...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("go tool pprof %s: %w: %s", file.Path, err, exitErr.Stderr)
+		}
+		return fmt.Errorf("go tool pprof %s: %w", file.Path, err)
+	}
+	_, err = w.Write(out)
+	return err
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProfilePart(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+	opts := profileOptions{Dir: dir, CPU: true, Mem: true, Trace: true}
+
+	ran := false
+	files, err := profilePart(opts, 7, 2, func() { ran = true })
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if !ran {
+		t.Errorf("The part was not run")
+	}
+
+	want := []profileFile{
+		{"cpu", filepath.Join(dir, "day-07-part-2.cpu.pprof")},
+		{"trace", filepath.Join(dir, "day-07-part-2.trace")},
+		{"mem", filepath.Join(dir, "day-07-part-2.mem.pprof")},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("Got wrong files: got %v, want %v", files, want)
+	}
+	for _, file := range files {
+		if info, err := os.Stat(file.Path); err != nil || info.Size() == 0 {
+			t.Errorf("Got no %s profile: %v", file.Kind, err)
+		}
+	}
+}
+
+func TestProfilePartOnlyWritesTheChosenProfiles(t *testing.T) {
+	dir := t.TempDir()
+	files, err := profilePart(profileOptions{Dir: dir, Mem: true}, 1, 1, func() {})
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(files) != 1 || files[0].Kind != "mem" || len(entries) != 1 {
+		t.Errorf("Got wrong files: %v, %v", files, entries)
+	}
+}
+
+func TestWriteTop(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go tool pprof is not available")
+	}
+
+	files, err := profilePart(profileOptions{Dir: t.TempDir(), Mem: true, Trace: true}, 1, 1, func() {})
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	for _, file := range files {
+		var out bytes.Buffer
+		if err := writeTop(&out, file, 3); err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		if got := out.String(); (file.Kind == "mem") != strings.Contains(got, "flat%") {
+			t.Errorf("Got wrong report for the %s profile:\n%s", file.Kind, got)
+		}
+	}
+}
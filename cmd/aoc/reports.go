@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	day2 "github.com/tejesh-kaliki/advent-of-code-2024/day-2"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+)
+
+func reportsCommand(args []string) error {
+	flags := flag.NewFlagSet("reports", flag.ContinueOnError)
+	inputPath := flags.String("input", "", `reports to check, or "-" for stdin (default: the cached input of day 2)`)
+	minStep := flags.Int("min-step", day2.DefaultPolicy.MinStep, "smallest change between two adjacent levels")
+	maxStep := flags.Int("max-step", day2.DefaultPolicy.MaxStep, "largest change between two adjacent levels")
+	directionName := flags.String("direction", day2.DefaultPolicy.Direction.String(), `way the levels must go: "either", "increasing" or "decreasing"`)
+	plateaus := flags.Bool("plateaus", day2.DefaultPolicy.AllowPlateaus, "allow two adjacent levels to be equal")
+	format := flags.String("format", "table", `output format: "table" or "json"`)
+	unsafeOnly := flags.Bool("unsafe", false, "only list the unsafe reports")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	direction, err := day2.ParseDirection(*directionName)
+	if err != nil {
+		return err
+	}
+	policy := day2.SafetyPolicy{MinStep: *minStep, MaxStep: *maxStep, Direction: direction, AllowPlateaus: *plateaus}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	write := day2.WriteTable
+	switch *format {
+	case "table":
+	case "json":
+		write = day2.WriteJSON
+	default:
+		return fmt.Errorf(`unknown format %q, want "table" or "json"`, *format)
+	}
+
+	input, err := inputs.Load(2, *inputPath)
+	if err != nil {
+		return err
+	}
+	diagnostics, err := policy.Diagnose(input)
+	if err != nil {
+		return err
+	}
+	if *unsafeOnly {
+		unsafe := make([]day2.Diagnostic, 0)
+		for _, d := range diagnostics {
+			if !d.Safe {
+				unsafe = append(unsafe, d)
+			}
+		}
+		diagnostics = unsafe
+	}
+	return write(os.Stdout, diagnostics)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/answers"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+	"github.com/tejesh-kaliki/advent-of-code-2024/memo"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+func runCommand(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	daySpec := flags.String("day", "all", `days to run: a day ("15"), a range ("1-10"), a list ("1,3,5") or "all"`)
+	part := flags.Int("part", 0, "part to run (1 or 2), or 0 for both parts")
+	inputPath := flags.String("input", "", `input file, or "-" for stdin (default: the cached input of each day)`)
+	record := flags.Bool("record", false, "record the answers as accepted, to be checked by verify")
+	all := flags.Bool("all", false, "run the parts of every day concurrently, and print a table of the results")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of parts run at the same time with --all")
+	timeout := flags.Duration("timeout", 0, "time limit of each part, or 0 for no limit (default: 1m with --all, none otherwise)")
+	cacheStats := flags.Bool("cache-stats", false, "print the statistics of the caches used by each part")
+	profile := profileOptions{}
+	flags.BoolVar(&profile.CPU, "cpuprofile", false, "write a CPU profile of each part")
+	flags.BoolVar(&profile.Mem, "memprofile", false, "write a memory allocation profile of each part")
+	flags.BoolVar(&profile.Trace, "trace", false, "write an execution trace of each part")
+	flags.StringVar(&profile.Dir, "profile-dir", "profiles", "directory the profiles and traces are written to")
+	flags.IntVar(&profile.Top, "top", 0, "print the N hottest functions of each profile, using go tool pprof")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("invalid part %d, it should be 1, 2 or 0 for both", *part)
+	}
+	if *all && *daySpec != "all" {
+		return errors.New("--all cannot be used with --day")
+	}
+	if *all && *cacheStats {
+		return errors.New("--cache-stats cannot be used with --all")
+	}
+	if *all && profile.enabled() {
+		return errors.New("profiles cannot be written with --all, as the parts run at the same time")
+	}
+	if profile.Top < 0 {
+		return fmt.Errorf("invalid --top %d, it should be 0 or more", profile.Top)
+	}
+	if *all && !isFlagSet(flags, "timeout") {
+		*timeout = allTimeout
+	}
+
+	days, err := selectDays(*daySpec)
+	if err != nil {
+		return err
+	}
+	if *inputPath != "" && len(days) != 1 {
+		return errors.New("--input can only be used when running a single day")
+	}
+
+	if *all {
+		return runConcurrently(days, selectParts(*part), *workers, *timeout, *record)
+	}
+
+	for _, day := range days {
+		input, err := inputs.Load(day.Number, *inputPath)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range selectParts(*part) {
+			solver := day.Part(p)
+			if solver == nil {
+				fmt.Printf("Day %d, Part %d: not solved\n", day.Number, p)
+				continue
+			}
+			ctx := context.Background()
+			collector := &memo.Collector{}
+			if *cacheStats {
+				ctx = memo.NewContext(ctx, collector)
+			}
+			var result partResult
+			run := func() {
+				result = runPart(ctx, solver, input, *timeout)
+			}
+			var files []profileFile
+			if profile.enabled() {
+				if files, err = profilePart(profile, day.Number, p, run); err != nil {
+					return err
+				}
+			} else {
+				run()
+			}
+			if result.Status != statusOK {
+				return fmt.Errorf("day %d, part %d: %w", day.Number, p, result.Err)
+			}
+			fmt.Printf("Day %d, Part %d: %v\n", day.Number, p, result.Answer)
+			for _, stats := range collector.Stats() {
+				fmt.Printf("  cache %s: %v\n", stats.Name, stats.Stats)
+			}
+			for _, file := range files {
+				fmt.Printf("  %s profile: %s\n", file.Kind, file.Path)
+				if profile.Top > 0 {
+					if err := writeTop(os.Stdout, file, profile.Top); err != nil {
+						return err
+					}
+				}
+			}
+
+			if *record {
+				if err := answers.Record(day.Number, p, answers.Format(result.Answer)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Time limit of each part run with --all, so that a slow part does not hold
+// the table. A single part runs as long as it needs, unless limited.
+const allTimeout = time.Minute
+
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) { set = set || f.Name == name })
+	return set
+}
+
+// Runs the solved parts of the days in a pool of workers. A part failing,
+// panicking or running out of time does not stop the others.
+func runConcurrently(days []registry.Day, parts []int, workers int, timeout time.Duration, record bool) error {
+	jobs := make([]partJob, 0, len(days)*len(parts))
+	for _, day := range days {
+		input, inputErr := inputs.Load(day.Number, "")
+		for _, p := range parts {
+			if solver := day.Part(p); solver != nil {
+				jobs = append(jobs, partJob{day.Number, p, solver, input, inputErr})
+			}
+		}
+	}
+
+	results := runPool(context.Background(), jobs, workers, timeout)
+	if err := writeResults(os.Stdout, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status != statusOK {
+			failed++
+			continue
+		}
+		if record {
+			if err := answers.Record(result.Day, result.Part, answers.Format(result.Answer)); err != nil {
+				return err
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d parts did not finish", failed, len(results))
+	}
+	return nil
+}
+
+func selectParts(part int) []int {
+	if part == 0 {
+		return []int{1, 2}
+	}
+	return []int{part}
+}
+
+// Returns the registered days matching the spec, ordered by day number.
+func selectDays(spec string) ([]registry.Day, error) {
+	if spec == "all" {
+		return registry.All(), nil
+	}
+
+	numbers, err := parseDaySpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]registry.Day, 0, len(numbers))
+	for _, number := range numbers {
+		day, found := registry.Get(number)
+		if !found {
+			return nil, fmt.Errorf("day %d has no solution", number)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// Parses a comma separated list of days and day ranges, like "1-3,7".
+// The returned day numbers are sorted and do not contain duplicates.
+func parseDaySpec(spec string) ([]int, error) {
+	numbers := make([]int, 0)
+	for _, item := range strings.Split(spec, ",") {
+		startText, endText, isRange := strings.Cut(item, "-")
+		if !isRange {
+			endText = startText
+		}
+
+		start, err := parseDayNumber(startText)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseDayNumber(endText)
+		if err != nil {
+			return nil, err
+		}
+		if start > end {
+			return nil, fmt.Errorf("invalid day range %q", item)
+		}
+
+		for day := start; day <= end; day++ {
+			numbers = append(numbers, day)
+		}
+	}
+
+	slices.Sort(numbers)
+	return slices.Compact(numbers), nil
+}
+
+func parseDayNumber(text string) (int, error) {
+	day, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || day < 1 || day > 25 {
+		return 0, fmt.Errorf("invalid day %q, it should be a number from 1 to 25", text)
+	}
+	return day, nil
+}
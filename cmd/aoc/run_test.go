@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+)
+
+func TestParseDaySpec(t *testing.T) {
+	testcases := []struct {
+		Name string
+		Spec string
+		Want []int
+	}{
+		{"single day", "15", []int{15}},
+		{"range of days", "3-6", []int{3, 4, 5, 6}},
+		{"list of days", "7,2,9", []int{2, 7, 9}},
+		{"list of days and ranges", "1-3,7", []int{1, 2, 3, 7}},
+		{"overlapping ranges are merged", "1-3,2-4", []int{1, 2, 3, 4}},
+		{"spaces around days are ignored", " 1, 2 ", []int{1, 2}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got, err := parseDaySpec(testcase.Spec)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if !slices.Equal(got, testcase.Want) {
+				t.Errorf("Got wrong days: got %v, want %v", got, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestParseDaySpecErrors(t *testing.T) {
+	for _, spec := range []string{"", "0", "26", "a", "5-3", "1-", "1,,2"} {
+		t.Run(spec, func(t *testing.T) {
+			if days, err := parseDaySpec(spec); err == nil {
+				t.Errorf("Got no error for %q, got days %v", spec, days)
+			}
+		})
+	}
+}
+
+func TestIsFlagSet(t *testing.T) {
+	testcases := []struct {
+		Name string
+		Args []string
+		Want bool
+	}{
+		{"flag not given", []string{"--all"}, false},
+		{"flag given", []string{"--timeout", "10s"}, true},
+		{"flag given with the default value", []string{"--timeout=0"}, true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			flags := flag.NewFlagSet("run", flag.ContinueOnError)
+			flags.Bool("all", false, "")
+			flags.Duration("timeout", 0, "")
+			if err := flags.Parse(testcase.Args); err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if got := isFlagSet(flags, "timeout"); got != testcase.Want {
+				t.Errorf("Got wrong output: got %v, want %v", got, testcase.Want)
+			}
+		})
+	}
+}
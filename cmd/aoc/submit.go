@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/answers"
+	"github.com/tejesh-kaliki/advent-of-code-2024/client"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+func submitCommand(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ContinueOnError)
+	dayNumber := flags.Int("day", 0, "day of the answer")
+	part := flags.Int("part", 0, "part of the answer (1 or 2)")
+	inputPath := flags.String("input", "", `input file, or "-" for stdin (default: the cached input of the day)`)
+	answer := flags.String("answer", "", "answer to submit (default: the answer of the solver)")
+	baseURL := flags.String("base-url", "", "address of the website (default: $AOC_BASE_URL or "+client.DefaultBaseURL+")")
+	wait := flags.Bool("wait", false, "wait for the submissions to be allowed again, instead of failing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *dayNumber < 1 || *dayNumber > 25 {
+		return errors.New("--day is required, and should be a number from 1 to 25")
+	}
+	if *part != 1 && *part != 2 {
+		return errors.New("--part is required, and should be 1 or 2")
+	}
+
+	if *answer == "" {
+		solved, err := solvePart(*dayNumber, *part, *inputPath)
+		if err != nil {
+			return err
+		}
+		*answer = solved
+	}
+
+	session, err := client.LoadSession()
+	if err != nil {
+		return err
+	}
+	c := client.New(session)
+	if *baseURL != "" {
+		c.BaseURL = *baseURL
+	}
+	throttle, err := client.NewThrottle()
+	if err != nil {
+		return err
+	}
+
+	if *wait {
+		remaining, err := throttle.Remaining(time.Now())
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			fmt.Printf("Waiting %v before submitting\n", remaining.Round(time.Second))
+			time.Sleep(remaining)
+		}
+	}
+
+	verdict, err := submitAnswer(context.Background(), c, throttle, *dayNumber, *part, *answer, time.Now())
+	if err != nil {
+		return err
+	}
+
+	switch verdict.Outcome {
+	case client.Correct:
+		fmt.Printf("Day %d, Part %d: %s is correct\n", *dayNumber, *part, *answer)
+	case client.AlreadySolved:
+		fmt.Printf("Day %d, Part %d: already solved\n", *dayNumber, *part)
+	case client.RateLimited:
+		return fmt.Errorf("submitted too recently, wait %v before trying again", verdict.Wait)
+	default:
+		return fmt.Errorf("%s is %s", *answer, verdict.Outcome)
+	}
+	return nil
+}
+
+// Runs the registered solver of one part of a day, and returns its answer.
+func solvePart(dayNumber, part int, inputPath string) (string, error) {
+	day, found := registry.Get(dayNumber)
+	if !found || day.Part(part) == nil {
+		return "", fmt.Errorf("day %d, part %d is not solved, pass the answer with --answer", dayNumber, part)
+	}
+
+	input, err := inputs.Load(dayNumber, inputPath)
+	if err != nil {
+		return "", err
+	}
+	answer, err := day.Part(part)(context.Background(), input)
+	if err != nil {
+		return "", fmt.Errorf("day %d, part %d: %w", dayNumber, part, err)
+	}
+	return answers.Format(answer), nil
+}
+
+// Submits an answer, unless the answers accepted or rejected by the website
+// already tell whether it is right or wrong, and records the verdict. An
+// answer only recorded from a run is submitted like any other. A wrong answer
+// or a rate limit puts the next submissions on hold for the time given by the
+// website.
+func submitAnswer(ctx context.Context, c *client.Client, throttle client.Throttle, day, part int, answer string, now time.Time) (client.Verdict, error) {
+	known, err := answers.Load(day)
+	if err != nil {
+		return client.Verdict{}, err
+	}
+
+	if known.Accepted(part) {
+		if accepted := known.Get(part); accepted != answer {
+			return client.Verdict{}, fmt.Errorf("part %d of day %d was solved with %s, not submitting %s", part, day, accepted, answer)
+		}
+		return client.Verdict{Outcome: client.AlreadySolved}, nil
+	}
+	if guess, wrong := known.KnownWrong(part, answer); wrong {
+		reason := "was rejected before"
+		if guess.Answer != answer {
+			reason = fmt.Sprintf("is wrong, because %s was %s", guess.Answer, guess.Hint)
+		}
+		return client.Verdict{}, fmt.Errorf("not submitting %s, it %s", answer, reason)
+	}
+
+	remaining, err := throttle.Remaining(now)
+	if err != nil {
+		return client.Verdict{}, err
+	}
+	if remaining > 0 {
+		return client.Verdict{}, fmt.Errorf("submissions are on hold for %v, try again later or use --wait", remaining.Round(time.Second))
+	}
+
+	verdict, err := c.Submit(ctx, day, part, answer)
+	if err != nil {
+		return client.Verdict{}, err
+	}
+
+	switch verdict.Outcome {
+	case client.Correct:
+		err = answers.RecordAccepted(day, part, answer)
+	case client.TooHigh:
+		err = recordWrong(throttle, day, part, answers.Guess{Answer: answer, Hint: answers.TooHigh}, now.Add(verdict.Wait))
+	case client.TooLow:
+		err = recordWrong(throttle, day, part, answers.Guess{Answer: answer, Hint: answers.TooLow}, now.Add(verdict.Wait))
+	case client.Wrong:
+		err = recordWrong(throttle, day, part, answers.Guess{Answer: answer}, now.Add(verdict.Wait))
+	case client.RateLimited:
+		err = throttle.Hold(now.Add(verdict.Wait))
+	case client.Unknown:
+		err = fmt.Errorf("could not understand the response: %q", verdict.Message)
+	}
+	return verdict, err
+}
+
+func recordWrong(throttle client.Throttle, day, part int, guess answers.Guess, until time.Time) error {
+	if err := answers.RecordWrong(day, part, guess); err != nil {
+		return err
+	}
+	return throttle.Hold(until)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/answers"
+	"github.com/tejesh-kaliki/advent-of-code-2024/client"
+)
+
+// Fake website, accepting 48 as the answer of every part. Numbers below it
+// are too low, and the others are too high.
+func newFakeSite(t *testing.T) (*client.Client, *int) {
+	t.Helper()
+
+	submissions := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		submissions++
+		var text string
+		switch answer := r.FormValue("answer"); {
+		case answer == "48":
+			text = "That's the right answer!"
+		case answer == "99":
+			text = "That's not the right answer; your answer is too high. Because you have guessed incorrectly 6 times on this puzzle, please wait 5 minutes before trying again."
+		case answer == "wait":
+			text = "You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 30s left to wait."
+		case answer < "48":
+			text = "That's not the right answer; your answer is too low."
+		default:
+			text = "That's not the right answer; your answer is too high."
+		}
+		w.Write([]byte("<main><article><p>" + text + "</p></article></main>"))
+	}))
+	t.Cleanup(server.Close)
+
+	return &client.Client{BaseURL: server.URL, Session: "secret", HTTPClient: server.Client()}, &submissions
+}
+
+func TestSubmitAnswer(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	c, submissions := newFakeSite(t)
+	throttle := client.Throttle{Path: filepath.Join(t.TempDir(), "next-submit")}
+	now := time.Date(2024, 12, 3, 6, 0, 0, 0, time.UTC)
+
+	verdict, err := submitAnswer(context.Background(), c, throttle, 3, 2, "50", now)
+	if err != nil || verdict.Outcome != client.TooHigh {
+		t.Fatalf("Got wrong verdict: got %v, %v, want %v", verdict.Outcome, err, client.TooHigh)
+	}
+
+	// A wrong answer puts the submissions on hold for a minute.
+	if _, err := submitAnswer(context.Background(), c, throttle, 3, 2, "47", now.Add(30*time.Second)); err == nil {
+		t.Errorf("Got no error when submitting during the hold")
+	}
+
+	now = now.Add(2 * time.Minute)
+	for _, answer := range []string{"50", "61"} {
+		if _, err := submitAnswer(context.Background(), c, throttle, 3, 2, answer, now); err == nil {
+			t.Errorf("Got no error when submitting %s, known to be too high", answer)
+		}
+	}
+	if *submissions != 1 {
+		t.Errorf("Got wrong number of submissions: got %d, want 1", *submissions)
+	}
+
+	verdict, err = submitAnswer(context.Background(), c, throttle, 3, 2, "48", now)
+	if err != nil || verdict.Outcome != client.Correct {
+		t.Fatalf("Got wrong verdict: got %v, %v, want %v", verdict.Outcome, err, client.Correct)
+	}
+	known, err := answers.Load(3)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if known.Part2 != "48" || !known.Accepted(2) {
+		t.Errorf("Got wrong recorded answer: got %q, accepted %v, want %q accepted", known.Part2, known.Accepted(2), "48")
+	}
+
+	verdict, err = submitAnswer(context.Background(), c, throttle, 3, 2, "48", now)
+	if err != nil || verdict.Outcome != client.AlreadySolved {
+		t.Errorf("Got wrong verdict: got %v, %v, want %v", verdict.Outcome, err, client.AlreadySolved)
+	}
+	if *submissions != 2 {
+		t.Errorf("Got wrong number of submissions: got %d, want 2", *submissions)
+	}
+}
+
+func TestSubmitAnswerWaitsAsLongAsAsked(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	c, _ := newFakeSite(t)
+	throttle := client.Throttle{Path: filepath.Join(t.TempDir(), "next-submit")}
+	now := time.Date(2024, 12, 3, 6, 0, 0, 0, time.UTC)
+
+	verdict, err := submitAnswer(context.Background(), c, throttle, 3, 2, "99", now)
+	if err != nil || verdict.Outcome != client.TooHigh {
+		t.Fatalf("Got wrong verdict: got %v, %v, want %v", verdict.Outcome, err, client.TooHigh)
+	}
+
+	remaining, err := throttle.Remaining(now.Add(4 * time.Minute))
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if remaining != time.Minute {
+		t.Errorf("Got wrong hold: got %v left after 4 minutes, want %v", remaining, time.Minute)
+	}
+}
+
+func TestSubmitAnswerRecordedByRun(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	c, submissions := newFakeSite(t)
+	throttle := client.Throttle{Path: filepath.Join(t.TempDir(), "next-submit")}
+	now := time.Date(2024, 12, 3, 6, 0, 0, 0, time.UTC)
+
+	// The answers recorded by "run --record" were never checked by the
+	// website, whether they are the answer submitted or another one.
+	for _, recorded := range []string{"48", "12"} {
+		if err := answers.Record(4, 1, recorded); err != nil {
+			t.Fatal(err)
+		}
+		verdict, err := submitAnswer(context.Background(), c, throttle, 4, 1, "48", now)
+		if err != nil || verdict.Outcome != client.Correct {
+			t.Errorf("Got wrong verdict with %s recorded: got %v, %v, want %v", recorded, verdict.Outcome, err, client.Correct)
+		}
+		if err := answers.Save(4, answers.Answers{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *submissions != 2 {
+		t.Errorf("Got wrong number of submissions: got %d, want 2", *submissions)
+	}
+}
+
+func TestSubmitAnswerRateLimited(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+	c, _ := newFakeSite(t)
+	throttle := client.Throttle{Path: filepath.Join(t.TempDir(), "next-submit")}
+	now := time.Date(2024, 12, 3, 6, 0, 0, 0, time.UTC)
+
+	verdict, err := submitAnswer(context.Background(), c, throttle, 5, 1, "wait", now)
+	if err != nil || verdict.Outcome != client.RateLimited {
+		t.Fatalf("Got wrong verdict: got %v, %v, want %v", verdict.Outcome, err, client.RateLimited)
+	}
+	if verdict.Wait != 90*time.Second {
+		t.Errorf("Got wrong wait: got %v, want %v", verdict.Wait, 90*time.Second)
+	}
+
+	remaining, err := throttle.Remaining(now)
+	if err != nil || remaining != 90*time.Second {
+		t.Errorf("Got wrong hold: got %v, %v, want %v", remaining, err, 90*time.Second)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/answers"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+type verifyStatus string
+
+const (
+	statusPass    verifyStatus = "pass"
+	statusFail    verifyStatus = "FAIL"
+	statusUnknown verifyStatus = "unknown"
+)
+
+type verifyResult struct {
+	Status verifyStatus
+	Detail string
+}
+
+func verifyCommand(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	daySpec := flags.String("day", "all", `days to verify: a day ("15"), a range ("1-10"), a list ("1,3,5") or "all"`)
+	part := flags.Int("part", 0, "part to verify (1 or 2), or 0 for both parts")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("invalid part %d, it should be 1, 2 or 0 for both", *part)
+	}
+
+	days, err := selectDays(*daySpec)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[verifyStatus]int)
+	for _, day := range days {
+		known, err := answers.Load(day.Number)
+		if err != nil {
+			return err
+		}
+
+		input, inputErr := inputs.Load(day.Number, "")
+		for _, p := range selectParts(*part) {
+			var result verifyResult
+			if inputErr != nil {
+				result = verifyResult{statusUnknown, "no input"}
+			} else {
+				result = checkAnswer(context.Background(), day.Part(p), input, known.Get(p))
+			}
+
+			counts[result.Status]++
+			fmt.Printf("Day %d, Part %d: %s", day.Number, p, result.Status)
+			if result.Detail != "" {
+				fmt.Printf(" (%s)", result.Detail)
+			}
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d failed, %d unknown\n", counts[statusPass], counts[statusFail], counts[statusUnknown])
+	if counts[statusFail] > 0 {
+		return errors.New("some answers do not match the recorded ones")
+	}
+	return nil
+}
+
+// Runs a solver and compares its answer with the recorded one. Without a
+// recorded answer, the result is unknown, whatever the solver returns.
+func checkAnswer(ctx context.Context, solver registry.Solver, input string, want string) verifyResult {
+	if solver == nil {
+		return verifyResult{statusUnknown, "not solved"}
+	}
+
+	answer, err := solver(ctx, input)
+	if err != nil {
+		return verifyResult{statusFail, err.Error()}
+	}
+
+	got := answers.Format(answer)
+	switch {
+	case want == "":
+		return verifyResult{statusUnknown, "no recorded answer, got " + got}
+	case got != want:
+		return verifyResult{statusFail, fmt.Sprintf("got %s, want %s", got, want)}
+	}
+	return verifyResult{statusPass, ""}
+}
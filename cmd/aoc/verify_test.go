@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+func TestCheckAnswer(t *testing.T) {
+	answer42 := func(ctx context.Context, input string) (any, error) { return 42, nil }
+	failing := func(ctx context.Context, input string) (any, error) { return nil, errors.New("line 1: bad input") }
+
+	testcases := []struct {
+		Name   string
+		Solver registry.Solver
+		Want   string
+		Result verifyResult
+	}{
+		{"same answer", answer42, "42", verifyResult{statusPass, ""}},
+		{"changed answer", answer42, "41", verifyResult{statusFail, "got 42, want 41"}},
+		{"no recorded answer", answer42, "", verifyResult{statusUnknown, "no recorded answer, got 42"}},
+		{"part not solved", nil, "42", verifyResult{statusUnknown, "not solved"}},
+		{"solver error", failing, "42", verifyResult{statusFail, "line 1: bad input"}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got := checkAnswer(context.Background(), testcase.Solver, "", testcase.Want)
+			if got != testcase.Result {
+				t.Errorf("Got wrong result: got %+v, want %+v", got, testcase.Result)
+			}
+		})
+	}
+}
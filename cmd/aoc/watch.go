@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	colourGreen = "\033[32m"
+	colourRed   = "\033[31m"
+	colourReset = "\033[0m"
+)
+
+// Extensions of the files whose changes trigger the tests: the sources, and
+// the examples and fixtures loaded by the tests.
+var watchedExtensions = []string{".go", ".txt", ".json", ".html"}
+
+func watchCommand(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: aoc watch [flags] [go test flags]")
+		flags.PrintDefaults()
+	}
+	root := flags.String("root", ".", "root directory of the module to watch")
+	interval := flags.Duration("interval", 500*time.Millisecond, "time between two scans of the files")
+	debounce := flags.Duration("debounce", 300*time.Millisecond, "time without changes to wait for before running the tests")
+	noColour := flags.Bool("no-color", os.Getenv("NO_COLOR") != "", "do not colour PASS and FAIL")
+	clearScreen := flags.Bool("clear", true, "clear the screen before every run")
+	verbose := flags.Bool("v", false, "print the output of the passing tests too")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	files, err := scanFiles(*root)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Watching %d files in %s\n", len(files), *root)
+
+	for {
+		changed, err := waitForChanges(*root, files, *interval, *debounce)
+		if err != nil {
+			return err
+		}
+
+		packages, err := listPackages(*root)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		affected := affectedPackages(changed, packages)
+		if len(affected) == 0 {
+			continue
+		}
+
+		if *clearScreen {
+			fmt.Print("\033[H\033[2J")
+		}
+		fmt.Printf("%s: testing %d packages\n", time.Now().Format(time.TimeOnly), len(affected))
+		if err := runTests(*root, affected, flags.Args(), os.Stdout, *verbose, !*noColour); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+}
+
+type fileState struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// Returns the state of the watched files under root, ignoring hidden
+// directories like .git.
+func scanFiles(root string) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != "go.mod" && !slices.Contains(watchedExtensions, filepath.Ext(path)) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		files[path] = fileState{ModTime: info.ModTime(), Size: info.Size()}
+		return nil
+	})
+	return files, err
+}
+
+// Returns the files that were added, changed or removed between two scans.
+func changedFiles(before, after map[string]fileState) []string {
+	changed := make([]string, 0)
+	for path, state := range after {
+		if previous, found := before[path]; !found || previous != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, found := after[path]; !found {
+			changed = append(changed, path)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// Polls the files until some of them change, and then until they stop
+// changing for the debounce time, so that saving several files at once runs
+// the tests only once. The files map is updated to the last scan.
+func waitForChanges(root string, files map[string]fileState, interval, debounce time.Duration) ([]string, error) {
+	changed := make([]string, 0)
+	wait := interval
+	for {
+		time.Sleep(wait)
+		current, err := scanFiles(root)
+		if err != nil {
+			return nil, err
+		}
+
+		newChanges := changedFiles(files, current)
+		clear(files)
+		for path, state := range current {
+			files[path] = state
+		}
+
+		if len(newChanges) == 0 && len(changed) > 0 {
+			slices.Sort(changed)
+			return slices.Compact(changed), nil
+		}
+		if len(newChanges) > 0 {
+			changed = append(changed, newChanges...)
+			wait = debounce
+		}
+	}
+}
+
+type listedPackage struct {
+	ImportPath   string
+	Dir          string
+	Deps         []string
+	TestImports  []string
+	XTestImports []string
+}
+
+func listPackages(root string) ([]listedPackage, error) {
+	cmd := exec.Command("go", "list", "-e", "-json=ImportPath,Dir,Deps,TestImports,XTestImports", "./...")
+	cmd.Dir = root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing packages: %w\n%s", err, stderr.String())
+	}
+
+	packages := make([]listedPackage, 0)
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for decoder.More() {
+		var pkg listedPackage
+		if err := decoder.Decode(&pkg); err != nil {
+			return nil, fmt.Errorf("listing packages: %w", err)
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// Returns the import paths of the packages to test after some files changed:
+// the packages holding the files, and the packages depending on them. Files
+// in a directory without a package, like testdata, belong to the package of
+// the closest parent directory. A change of go.mod affects every package.
+func affectedPackages(changed []string, packages []listedPackage) []string {
+	byDir := make(map[string]string)
+	for _, pkg := range packages {
+		byDir[pkg.Dir] = pkg.ImportPath
+	}
+
+	changedPackages := make([]string, 0)
+	for _, path := range changed {
+		path, _ = filepath.Abs(path)
+		if filepath.Base(path) == "go.mod" {
+			changedPackages = nil
+			for _, pkg := range packages {
+				changedPackages = append(changedPackages, pkg.ImportPath)
+			}
+			break
+		}
+
+		for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+			if importPath, found := byDir[dir]; found {
+				changedPackages = append(changedPackages, importPath)
+				break
+			}
+			if dir == filepath.Dir(dir) {
+				break
+			}
+		}
+	}
+
+	affected := make([]string, 0)
+	for _, pkg := range packages {
+		for _, changedPackage := range changedPackages {
+			if pkg.ImportPath == changedPackage ||
+				slices.Contains(pkg.Deps, changedPackage) ||
+				slices.Contains(pkg.TestImports, changedPackage) ||
+				slices.Contains(pkg.XTestImports, changedPackage) {
+				affected = append(affected, pkg.ImportPath)
+				break
+			}
+		}
+	}
+	return affected
+}
+
+// Event printed by "go test -json".
+type testEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+type packageResult struct {
+	Package string
+	Passed  bool
+	Elapsed float64
+}
+
+// Runs the tests of the packages, printing their output with coloured PASS
+// and FAIL, followed by a summary line per package.
+func runTests(root string, packages []string, testArgs []string, w io.Writer, verbose, colour bool) error {
+	args := append([]string{"test", "-json"}, testArgs...)
+	cmd := exec.Command("go", append(args, packages...)...)
+	cmd.Dir = root
+	cmd.Stderr = w
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	results := summarizeTests(stdout, w, verbose, colour)
+	// go test exits with an error when a test fails, which the summary shows.
+	cmd.Wait()
+
+	fmt.Fprintln(w)
+	for _, result := range results {
+		status := colourise("PASS", colour)
+		if !result.Passed {
+			status = colourise("FAIL", colour)
+		}
+		fmt.Fprintf(w, "%s  %-60s %.2fs\n", status, result.Package, result.Elapsed)
+	}
+	return nil
+}
+
+// Copies the output of "go test -json" to w, and returns the result of every
+// package, in the order they finished. Like "go test" without -v, the lines
+// of running and passing tests are left out unless verbose is set.
+func summarizeTests(r io.Reader, w io.Writer, verbose, colour bool) []packageResult {
+	results := make([]packageResult, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var event testEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			fmt.Fprintln(w, scanner.Text())
+			continue
+		}
+
+		switch {
+		case event.Action == "output" || event.Action == "build-output":
+			if verbose || !isProgressLine(event.Output) {
+				fmt.Fprint(w, colourise(event.Output, colour))
+			}
+		case event.Test == "" && (event.Action == "pass" || event.Action == "fail"):
+			results = append(results, packageResult{event.Package, event.Action == "pass", event.Elapsed})
+		}
+	}
+	return results
+}
+
+func isProgressLine(line string) bool {
+	line = strings.TrimLeft(line, " ")
+	if line == "PASS\n" {
+		return true
+	}
+	for _, prefix := range []string{"=== ", "--- PASS", "--- SKIP"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func colourise(text string, colour bool) string {
+	if !colour {
+		return text
+	}
+	text = strings.ReplaceAll(text, "PASS", colourGreen+"PASS"+colourReset)
+	return strings.ReplaceAll(text, "FAIL", colourRed+"FAIL"+colourReset)
+}
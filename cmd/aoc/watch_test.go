@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanAndChangedFiles(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("day-1/main.go", "package day1\n")
+	write("day-1/testdata/example-1.txt", "3   4\n")
+	write("day-1/notes.md", "ignored")
+	write(".git/HEAD", "ignored.go")
+
+	before, err := scanFiles(root)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if len(before) != 2 {
+		t.Errorf("Got wrong files: got %v, want main.go and example-1.txt", before)
+	}
+
+	write("day-1/main.go", "package day1\n\nfunc A() {}\n")
+	write("grid/grid.go", "package grid\n")
+	if err := os.Remove(filepath.Join(root, "day-1/testdata/example-1.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := scanFiles(root)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "day-1/main.go"),
+		filepath.Join(root, "day-1/testdata/example-1.txt"),
+		filepath.Join(root, "grid/grid.go"),
+	}
+	if got := changedFiles(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong changed files: got %v, want %v", got, want)
+	}
+}
+
+func TestAffectedPackages(t *testing.T) {
+	packages := []listedPackage{
+		{ImportPath: "aoc/grid", Dir: "/repo/grid"},
+		{ImportPath: "aoc/parse", Dir: "/repo/parse"},
+		{ImportPath: "aoc/examples", Dir: "/repo/examples"},
+		{ImportPath: "aoc/day-1", Dir: "/repo/day-1", Deps: []string{"aoc/parse"}, TestImports: []string{"aoc/examples"}},
+		{ImportPath: "aoc/day-10", Dir: "/repo/day-10", Deps: []string{"aoc/grid", "aoc/parse"}},
+	}
+
+	testcases := []struct {
+		Name    string
+		Changed []string
+		Want    []string
+	}{
+		{"file of a day", []string{"/repo/day-10/main.go"}, []string{"aoc/day-10"}},
+		{"fixture of a day", []string{"/repo/day-1/testdata/examples.json"}, []string{"aoc/day-1"}},
+		{"shared package", []string{"/repo/grid/grid.go"}, []string{"aoc/grid", "aoc/day-10"}},
+		{"package imported by tests", []string{"/repo/examples/testing.go"}, []string{"aoc/examples", "aoc/day-1"}},
+		{"several files", []string{"/repo/day-1/main.go", "/repo/day-10/main_test.go"}, []string{"aoc/day-1", "aoc/day-10"}},
+		{"go.mod", []string{"/repo/go.mod"}, []string{"aoc/grid", "aoc/parse", "aoc/examples", "aoc/day-1", "aoc/day-10"}},
+		{"file outside packages", []string{"/repo/README.txt"}, []string{}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got := affectedPackages(testcase.Changed, packages)
+			if !reflect.DeepEqual(got, testcase.Want) {
+				t.Errorf("Got wrong packages: got %v, want %v", got, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestSummarizeTests(t *testing.T) {
+	events := `{"Action":"run","Package":"aoc/day-1","Test":"TestA"}
+{"Action":"output","Package":"aoc/day-1","Test":"TestA","Output":"=== RUN   TestA\n"}
+{"Action":"output","Package":"aoc/day-1","Test":"TestB","Output":"    --- PASS: TestB/case (0.00s)\n"}
+{"Action":"output","Package":"aoc/day-1","Test":"TestA","Output":"--- FAIL: TestA (0.00s)\n"}
+{"Action":"fail","Package":"aoc/day-1","Test":"TestA","Elapsed":0}
+{"Action":"output","Package":"aoc/day-1","Output":"FAIL\n"}
+{"Action":"fail","Package":"aoc/day-1","Elapsed":0.25}
+{"Action":"output","Package":"aoc/grid","Output":"PASS\n"}
+{"Action":"output","Package":"aoc/grid","Output":"ok  \taoc/grid\t0.5s\n"}
+{"Action":"pass","Package":"aoc/grid","Elapsed":0.5}
+`
+	var output bytes.Buffer
+	got := summarizeTests(strings.NewReader(events), &output, false, true)
+
+	want := []packageResult{{"aoc/day-1", false, 0.25}, {"aoc/grid", true, 0.5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong results: got %v, want %v", got, want)
+	}
+
+	wantOutput := "--- \033[31mFAIL\033[0m: TestA (0.00s)\n\033[31mFAIL\033[0m\nok  \taoc/grid\t0.5s\n"
+	if output.String() != wantOutput {
+		t.Errorf("Got wrong output: got %q, want %q", output.String(), wantOutput)
+	}
+}
+
+func TestWaitForChangesDebounces(t *testing.T) {
+	root := t.TempDir()
+	files, err := scanFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for _, name := range []string{"a.go", "b.go", "c.go"} {
+			os.WriteFile(filepath.Join(root, name), []byte("package a\n"), 0o644)
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	changed, err := waitForChanges(root, files, 10*time.Millisecond, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if len(changed) != 3 {
+		t.Errorf("Got wrong changed files: got %v, want the 3 files", changed)
+	}
+	if len(files) != 3 {
+		t.Errorf("Files were not updated to the last scan: %v", files)
+	}
+}
@@ -0,0 +1,32 @@
+package day1
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// Generate returns the given number of pairs of five digit locations. As in
+// the puzzle, some locations of the right list are also in the left list,
+// some of them several times.
+func Generate(rng *rand.Rand, pairs int) string {
+	left := make([]int, pairs)
+	for i := range left {
+		left[i] = 10000 + rng.IntN(90000)
+	}
+
+	var builder strings.Builder
+	for _, loc := range left {
+		right := 10000 + rng.IntN(90000)
+		if rng.IntN(4) == 0 {
+			right = left[rng.IntN(min(len(left), 20))]
+		}
+		fmt.Fprintf(&builder, "%d   %d\n", loc, right)
+	}
+	return builder.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 1000)
+}
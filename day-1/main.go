@@ -1,38 +1,29 @@
-package main
+package day1
 
 import (
-	_ "embed"
-	"fmt"
-	"log"
-	"slices"
-	"strconv"
+	"context"
 	"strings"
-)
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
-func parseInputLine(line string) (int, int) {
-	locs := strings.Split(line, " ")
-	loc1, err := strconv.ParseInt(locs[0], 10, 64)
-	if err != nil {
-		log.Fatalln("Not able to process input:", err)
-	}
-	loc2, err := strconv.ParseInt(locs[len(locs)-1], 10, 64)
-	if err != nil {
-		log.Fatalln("Not able to process input:", err)
+// Parses the locations of a line, which must have [width] of them, or any
+// number if [width] is 0.
+func parseRow(line string, lineNumber, width int) ([]int, error) {
+	locs := parse.Fields(line)
+	if (width != 0 && len(locs) != width) || len(locs) == 0 {
+		return nil, parse.Errorf(lineNumber, 0, "want %d locations, got %d", max(width, 1), len(locs))
 	}
-	return int(loc1), int(loc2)
-}
 
-func getLocationLists(input string) ([]int, []int) {
-	lines := strings.Split(input, "\n")
-	firstList := make([]int, len(lines))
-	secondList := make([]int, len(lines))
-	for i, line := range lines {
-		firstList[i], secondList[i] = parseInputLine(line)
+	row := make([]int, len(locs))
+	for i, loc := range locs {
+		var err error
+		if row[i], err = parse.Int(loc.Text, lineNumber, loc.Column); err != nil {
+			return nil, err
+		}
 	}
-	return firstList, secondList
+	return row, nil
 }
 
 func findDistance(loc1, loc2 int) int {
@@ -42,39 +33,24 @@ func findDistance(loc1, loc2 int) int {
 	return loc2 - loc1
 }
 
-func TotalDistanceBetweenLocations(input string) int {
-	firstList, secondList := getLocationLists(input)
-	slices.Sort(firstList)
-	slices.Sort(secondList)
-
-	totalDistance := 0
-	for i := range firstList {
-		totalDistance += findDistance(firstList[i], secondList[i])
-	}
-	return totalDistance
-}
-
-func countElements[T comparable](items []T, element T) int {
-	count := 0
-	for _, x := range items {
-		if x == element {
-			count++
-		}
-	}
-	return count
+func TotalDistanceBetweenLocations(input string) (int, error) {
+	opts := DistanceOptions{Width: 2, Columns: []int{0, 1}, Sort: DefaultSortOptions}
+	return TotalDistance(strings.NewReader(input), opts)
 }
 
-func SimilarityScoresBetweenLocations(input string) int {
-	firstList, secondList := getLocationLists(input)
-	total := 0
-	for _, element := range firstList {
-		count := countElements(secondList, element)
-		total += count * element
-	}
-	return total
+func SimilarityScoresBetweenLocations(input string) (int, error) {
+	return SimilarityScore(strings.NewReader(input))
 }
 
-func main() {
-	fmt.Println("Part 1 solution:", TotalDistanceBetweenLocations(input))
-	fmt.Println("Part 2 solution:", SimilarityScoresBetweenLocations(input))
+func init() {
+	registry.Register(registry.Day{
+		Number: 1,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			return TotalDistanceBetweenLocations(input)
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			return SimilarityScoresBetweenLocations(input)
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,6 +1,18 @@
-package main
+package day1
 
-import "testing"
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
+)
 
 func TestTotalDistanceBetweenLocations(t *testing.T) {
 	testcases := []struct {
@@ -23,7 +35,10 @@ func TestTotalDistanceBetweenLocations(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			distance := TotalDistanceBetweenLocations(testcase.Input)
+			distance, err := TotalDistanceBetweenLocations(testcase.Input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
 			if distance != testcase.Want {
 				t.Errorf("Got wrong distance. Got %d, want %d", distance, testcase.Want)
 			}
@@ -53,10 +68,387 @@ func TestSimilarityScoresBetweenLocations(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			similarity := SimilarityScoresBetweenLocations(testcase.Input)
+			similarity, err := SimilarityScoresBetweenLocations(testcase.Input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
 			if similarity != testcase.Want {
 				t.Errorf("Got wrong similarity. Got %d, want %d", similarity, testcase.Want)
 			}
 		})
 	}
 }
+
+func TestReadInputErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"location is not a number", "3   4\n4   x3", `line 2, column 5: invalid number "x3"`},
+		{"line has a single location", "3   4\n4\n5   1", "line 2: want 2 locations, got 1"},
+		{"line has 3 locations", "3   4   5", "line 1: want 2 locations, got 3"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := TotalDistanceBetweenLocations(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestTrailingNewlineAndCRLF(t *testing.T) {
+	distance, err := TotalDistanceBetweenLocations("1  3\r\n4  2\r\n")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if distance != 2 {
+		t.Errorf("Got wrong distance. Got %d, want %d", distance, 2)
+	}
+}
+
+func TestReadPairsBlankLines(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"trailing blank lines are ignored", "1  3\n4  2\n\n\n", ""},
+		{"blank line before the end", "1  3\n\n4  2", "line 2: want 2 locations, got 0"},
+		{"blank first line", "\r\n1  3", "line 1: want 2 locations, got 0"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			err := ReadPairs(strings.NewReader(testcase.Input), func(left, right int) error { return nil })
+			if (err == nil && testcase.WantErr != "") || (err != nil && err.Error() != testcase.WantErr) {
+				t.Errorf("Got wrong error: got %v, want %q", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+// The sorting distance and the counting similarity, computed the slow way.
+func bruteForce(input string) (any, any) {
+	lines := parse.Lines(input)
+	left, right := make([]int, len(lines)), make([]int, len(lines))
+	for i, line := range lines {
+		row, _ := parseRow(line, i+1, 2)
+		left[i], right[i] = row[0], row[1]
+	}
+
+	similarity := 0
+	for _, l := range left {
+		for _, r := range right {
+			if l == r {
+				similarity += l
+			}
+		}
+	}
+
+	slices.Sort(left)
+	slices.Sort(right)
+	distance := 0
+	for i := range left {
+		distance += findDistance(left[i], right[i])
+	}
+	return distance, similarity
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(200)) }
+	proptest.CheckParts(t, 1, generate, bruteForce)
+}
+
+func TestTotalDistanceSpillsToDisk(t *testing.T) {
+	dir := t.TempDir()
+	input := Generate(proptest.NewRand(1), 1000)
+	want, _ := bruteForce(input)
+
+	for _, maxInMemory := range []int{0, 7, 999, 1000, 5000} {
+		opts := DistanceOptions{Columns: []int{0, 1}, Sort: SortOptions{MaxInMemory: maxInMemory, Dir: dir}}
+		got, err := TotalDistance(strings.NewReader(input), opts)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("Got wrong distance with %d in memory: got %d, want %d", maxInMemory, got, want)
+		}
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Got %d runs left on disk", len(entries))
+	}
+}
+
+func TestSorter(t *testing.T) {
+	values := []int{5, -3, 12, 0, 5, 1 << 40, -7, 3}
+	sorter := NewSorter(SortOptions{MaxInMemory: 3, Dir: t.TempDir()})
+	defer sorter.Close()
+	for _, value := range values {
+		if err := sorter.Add(value); err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+	}
+	if sorter.Runs() != 2 {
+		t.Errorf("Got wrong number of runs: got %d, want 2", sorter.Runs())
+	}
+
+	next, err := sorter.Sorted()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	got := make([]int, 0)
+	for value, ok, err := next(); ok || err != nil; value, ok, err = next() {
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		got = append(got, value)
+	}
+
+	want := slices.Sorted(slices.Values(values))
+	if !slices.Equal(got, want) {
+		t.Errorf("Got wrong order: got %v, want %v", got, want)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	testcases := []struct {
+		Name   string
+		Metric string
+		Loc1   int
+		Loc2   int
+		Want   int
+	}{
+		{"absolute difference", "abs", 3, 7, 4},
+		{"absolute difference, reversed", "abs", 7, 3, 4},
+		{"squared difference", "squared", 3, 7, 16},
+		{"bounded difference below the bound", "bounded:5", 3, 7, 4},
+		{"bounded difference above the bound", "bounded:5", 3, 70, 5},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			metric, err := ParseMetric(testcase.Metric)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if got := metric(testcase.Loc1, testcase.Loc2); got != testcase.Want {
+				t.Errorf("Got wrong output: got %d, want %d", got, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestParseMetricErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"unknown metric", "cubed", `unknown metric "cubed", want "abs", "squared" or "bounded:N"`},
+		{"bound is not a number", "bounded:x", `invalid bound "x", want a number of 0 or more`},
+		{"negative bound", "bounded:-1", `invalid bound "-1", want a number of 0 or more`},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := ParseMetric(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+const threeColumns = `3   4   3
+4   3   9
+2   5   3
+1   3   1
+3   9   4
+3   3   3`
+
+func TestTotalDistanceOfColumns(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Columns []int
+		Metric  Metric
+		Want    int
+	}{
+		{"first two columns", []int{0, 1}, nil, 11},
+		{"columns in any order", []int{2, 0}, nil, 7},
+		{"every pair of columns", nil, nil, 11 + 7 + 4},
+		{"squared", []int{0, 1}, Squared, 35},
+		{"bounded", nil, Bounded(1), 5 + 3 + 3},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			opts := DistanceOptions{Columns: testcase.Columns, Metric: testcase.Metric}
+			got, err := TotalDistance(strings.NewReader(threeColumns), opts)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if got != testcase.Want {
+				t.Errorf("Got wrong distance. Got %d, want %d", got, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestTotalDistanceOfColumnsErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		Columns []int
+		WantErr string
+	}{
+		{"lines of different widths", "1 2 3\n4 5", nil, "line 2: want 3 locations, got 2"},
+		{"column out of the lists", "1 2 3\n4 5 6", []int{0, 3}, "column 3 does not exist, the lists have 3 columns"},
+		{"single column", "1 2", []int{1}, "want 2 columns to compare, got 1"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := TotalDistance(strings.NewReader(testcase.Input), DistanceOptions{Columns: testcase.Columns})
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	comparison, err := Compare(strings.NewReader(threeColumns), Absolute, SortOptions{MaxInMemory: 4, Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := `  distance  column 1  column 2  column 3
+  column 1         0        11         7
+  column 2        11         0         4
+  column 3         7         4         0
+
+  similarity  column 1  column 2  column 3
+    column 1        34        31        32
+    column 2        31        45        40
+    column 3        32        40        41
+`
+	var out bytes.Buffer
+	if err := comparison.Write(&out); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if out.String() != want {
+		t.Errorf("Got wrong report:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestCompareMatchesTheParts(t *testing.T) {
+	proptest.Check(t, func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(100)) }, func(input string) error {
+		comparison, err := Compare(strings.NewReader(input), Absolute, SortOptions{})
+		if err != nil {
+			return err
+		}
+		distance, similarity := bruteForce(input)
+		if comparison.Distance[0][1] != distance || comparison.Similarity[0][1] != similarity {
+			return fmt.Errorf("got %d and %d, want %d and %d", comparison.Distance[0][1], comparison.Similarity[0][1], distance, similarity)
+		}
+		return nil
+	})
+}
+
+func TestCompareSpillsLargeRuns(t *testing.T) {
+	// Runs of 5000 locations take more than the 4 KB buffer of a reader, so
+	// two readers of the same run would see each other's reads.
+	rng := proptest.NewRand(2)
+	rows, width := 40000, 3
+	columns := make([][]int, width)
+	var builder strings.Builder
+	for range rows {
+		for i := range columns {
+			loc := 10000 + rng.IntN(90000)
+			columns[i] = append(columns[i], loc)
+			fmt.Fprintf(&builder, "%d   ", loc)
+		}
+		builder.WriteString("\n")
+	}
+
+	dir := t.TempDir()
+	comparison, err := Compare(strings.NewReader(builder.String()), Absolute, SortOptions{MaxInMemory: 5000, Dir: dir})
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	for i := range columns {
+		for j := range columns {
+			left, right := slices.Sorted(slices.Values(columns[i])), slices.Sorted(slices.Values(columns[j]))
+			counts := make(map[int]int)
+			for _, loc := range right {
+				counts[loc]++
+			}
+			distance, similarity := 0, 0
+			for k, loc := range left {
+				distance += Absolute(loc, right[k])
+				similarity += loc * counts[loc]
+			}
+			if comparison.Distance[i][j] != distance || comparison.Similarity[i][j] != similarity {
+				t.Errorf("Got wrong comparison of columns %d and %d: got %d and %d, want %d and %d",
+					i+1, j+1, comparison.Distance[i][j], comparison.Similarity[i][j], distance, similarity)
+			}
+		}
+	}
+
+	got, err := TotalDistance(strings.NewReader(builder.String()), DistanceOptions{Columns: []int{1, 1}, Sort: SortOptions{MaxInMemory: 5000, Dir: dir}})
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got != 0 {
+		t.Errorf("Got wrong distance of a column to itself: got %d, want 0", got)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Got %d runs left on disk", len(entries))
+	}
+}
+
+func TestSorterReadTwiceAtOnce(t *testing.T) {
+	sorter := NewSorter(SortOptions{MaxInMemory: 3000, Dir: t.TempDir()})
+	defer sorter.Close()
+	for value := 10000; value > 0; value-- {
+		if err := sorter.Add(value * 1000); err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+	}
+
+	first, err := sorter.Sorted()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	second, err := sorter.Sorted()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	for want := 1; want <= 10000; want++ {
+		for _, next := range []func() (int, bool, error){first, second} {
+			got, ok, err := next()
+			if err != nil || !ok || got != want*1000 {
+				t.Fatalf("Got wrong value: got %d %v %v, want %d", got, ok, err, want*1000)
+			}
+		}
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 1, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 1, 2)
+}
+
+func TestExamples(t *testing.T) {
+	testutil.Examples(t, 1)
+}
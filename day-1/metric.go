@@ -0,0 +1,49 @@
+package day1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Metric is the distance between two locations.
+type Metric func(loc1, loc2 int) int
+
+// Absolute is the difference of the locations, as in the puzzle.
+func Absolute(loc1, loc2 int) int {
+	return findDistance(loc1, loc2)
+}
+
+// Squared is the square of the difference, making large differences count
+// more than many small ones.
+func Squared(loc1, loc2 int) int {
+	diff := loc1 - loc2
+	return diff * diff
+}
+
+// Bounded returns the difference of the locations, but at most limit, so that
+// a few outliers do not hide how close the rest of the lists are.
+func Bounded(limit int) Metric {
+	return func(loc1, loc2 int) int {
+		return min(findDistance(loc1, loc2), limit)
+	}
+}
+
+// ParseMetric returns the metric of a name: "abs", "squared" or "bounded:N".
+func ParseMetric(name string) (Metric, error) {
+	switch name {
+	case "abs":
+		return Absolute, nil
+	case "squared":
+		return Squared, nil
+	}
+
+	if limitText, found := strings.CutPrefix(name, "bounded:"); found {
+		limit, err := strconv.Atoi(limitText)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid bound %q, want a number of 0 or more", limitText)
+		}
+		return Bounded(limit), nil
+	}
+	return nil, fmt.Errorf(`unknown metric %q, want "abs", "squared" or "bounded:N"`, name)
+}
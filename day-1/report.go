@@ -0,0 +1,149 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Comparison holds the distance and the similarity of every pair of columns
+// of the lists, indexed by the columns.
+type Comparison struct {
+	Distance   [][]int
+	Similarity [][]int
+}
+
+// Compare computes the distance, with the given metric, and the similarity of
+// every pair of columns, reading the lists once. The similarity of two columns
+// adds up every location of one column times the number of times it is in
+// the other one, as in part 2. Both are computed from the sorted columns, so
+// that the lists never have to be held in memory. The distance of a column to
+// itself is 0.
+func Compare(r io.Reader, metric Metric, sort SortOptions) (Comparison, error) {
+	sorters := make([]*Sorter, 0)
+	defer func() { closeSorters(sorters) }()
+	err := ReadRows(r, 0, func(row []int) error {
+		for len(sorters) < len(row) {
+			sorters = append(sorters, NewSorter(sort))
+		}
+		for i, loc := range row {
+			if err := sorters[i].Add(loc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	width := len(sorters)
+	comparison := Comparison{Distance: newMatrix(width), Similarity: newMatrix(width)}
+	for i := range width {
+		for j := i; j < width; j++ {
+			similarity, err := sortedSimilarity(sorters[i], sorters[j])
+			if err != nil {
+				return Comparison{}, err
+			}
+			comparison.Similarity[i][j], comparison.Similarity[j][i] = similarity, similarity
+			if i == j {
+				continue
+			}
+
+			distance, err := sortedDistance(sorters[i], sorters[j], metric)
+			if err != nil {
+				return Comparison{}, err
+			}
+			comparison.Distance[i][j], comparison.Distance[j][i] = distance, distance
+		}
+	}
+	return comparison, nil
+}
+
+func newMatrix(width int) [][]int {
+	matrix := make([][]int, width)
+	for i := range matrix {
+		matrix[i] = make([]int, width)
+	}
+	return matrix
+}
+
+// Computes the similarity of two sorted columns, walking them side by side:
+// a location found a times in one column and b times in the other adds a*b
+// times the location.
+func sortedSimilarity(left, right *Sorter) (int, error) {
+	lefts, err := left.Sorted()
+	if err != nil {
+		return 0, err
+	}
+	rights, err := right.Sorted()
+	if err != nil {
+		return 0, err
+	}
+
+	l, lok, err := lefts()
+	if err != nil {
+		return 0, err
+	}
+	r, rok, err := rights()
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for lok && rok {
+		switch {
+		case l < r:
+			l, lok, err = lefts()
+		case l > r:
+			r, rok, err = rights()
+		default:
+			loc := l
+			leftCount, rightCount := 0, 0
+			for lok && l == loc && err == nil {
+				leftCount++
+				l, lok, err = lefts()
+			}
+			for rok && r == loc && err == nil {
+				rightCount++
+				r, rok, err = rights()
+			}
+			total += loc * leftCount * rightCount
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// Write writes the distance and the similarity matrices as tables, with a row
+// and a column for each column of the lists.
+func (c Comparison) Write(w io.Writer) error {
+	for k, table := range []struct {
+		Name   string
+		Matrix [][]int
+	}{{"distance", c.Distance}, {"similarity", c.Similarity}} {
+		if k > 0 {
+			fmt.Fprintln(w)
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		header := []string{table.Name}
+		for i := range table.Matrix {
+			header = append(header, fmt.Sprintf("column %d", i+1))
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t")+"\t")
+		for i, row := range table.Matrix {
+			cells := []string{fmt.Sprintf("column %d", i+1)}
+			for _, value := range row {
+				cells = append(cells, fmt.Sprint(value))
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t")+"\t")
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
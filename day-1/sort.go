@@ -0,0 +1,176 @@
+package day1
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"slices"
+)
+
+type SortOptions struct {
+	// Most numbers kept in memory. Once there are more, they are sorted in
+	// runs of this size written to temporary files, which are merged at the
+	// end. 0 keeps all the numbers in memory.
+	MaxInMemory int
+	// Directory of the temporary files, or "" for the default one.
+	Dir string
+}
+
+// DefaultSortOptions keeps up to 4M numbers, 32 MB, in memory per list.
+var DefaultSortOptions = SortOptions{MaxInMemory: 1 << 22}
+
+// Sorter sorts a list of numbers too large to be held in memory, spilling
+// sorted runs of numbers to disk. Every run keeps a file open, and it must be
+// closed to remove them.
+type Sorter struct {
+	opts SortOptions
+	buf  []int
+	runs []spilledRun
+}
+
+// A sorted run written to disk, with its size in bytes.
+type spilledRun struct {
+	f    *os.File
+	size int64
+}
+
+func NewSorter(opts SortOptions) *Sorter {
+	return &Sorter{opts: opts}
+}
+
+func (s *Sorter) Add(value int) error {
+	s.buf = append(s.buf, value)
+	if s.opts.MaxInMemory > 0 && len(s.buf) >= s.opts.MaxInMemory {
+		return s.spill()
+	}
+	return nil
+}
+
+// Runs returns the number of runs written to disk.
+func (s *Sorter) Runs() int {
+	return len(s.runs)
+}
+
+// Writes the numbers in memory to a new run, sorted.
+func (s *Sorter) spill() error {
+	f, err := os.CreateTemp(s.opts.Dir, "day-1-run-*")
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, spilledRun{f: f})
+	run := &s.runs[len(s.runs)-1]
+
+	slices.Sort(s.buf)
+	w := bufio.NewWriter(f)
+	var encoded []byte
+	for _, value := range s.buf {
+		encoded = binary.AppendVarint(encoded[:0], int64(value))
+		if _, err := w.Write(encoded); err != nil {
+			return err
+		}
+		run.size += int64(len(encoded))
+	}
+	s.buf = s.buf[:0]
+	return w.Flush()
+}
+
+// Sorted returns a function returning the numbers in increasing order, and
+// false once there are none left. Nothing can be added after. It can be
+// called again to read the numbers again, even while the previous functions
+// are still used: each one reads the runs at its own offsets.
+func (s *Sorter) Sorted() (next func() (int, bool, error), err error) {
+	slices.Sort(s.buf)
+	if len(s.runs) == 0 {
+		i := 0
+		return func() (int, bool, error) {
+			if i == len(s.buf) {
+				return 0, false, nil
+			}
+			i++
+			return s.buf[i-1], true, nil
+		}, nil
+	}
+
+	if len(s.buf) > 0 {
+		if err := s.spill(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Merges the runs, taking the smallest of their first numbers each time.
+	merge := make(runHeap, 0, len(s.runs))
+	for _, spilled := range s.runs {
+		r := &run{r: bufio.NewReader(io.NewSectionReader(spilled.f, 0, spilled.size))}
+		if err := r.advance(); err != nil {
+			return nil, err
+		}
+		if !r.done {
+			merge = append(merge, r)
+		}
+	}
+	heap.Init(&merge)
+
+	return func() (int, bool, error) {
+		if len(merge) == 0 {
+			return 0, false, nil
+		}
+		r := merge[0]
+		value := r.value
+		if err := r.advance(); err != nil {
+			return 0, false, err
+		}
+		if r.done {
+			heap.Pop(&merge)
+		} else {
+			heap.Fix(&merge, 0)
+		}
+		return value, true, nil
+	}, nil
+}
+
+// Close removes the runs written to disk.
+func (s *Sorter) Close() error {
+	errs := make([]error, 0)
+	for _, spilled := range s.runs {
+		errs = append(errs, spilled.f.Close(), os.Remove(spilled.f.Name()))
+	}
+	s.runs = nil
+	return errors.Join(errs...)
+}
+
+// A sorted run being merged, with the next number read from it.
+type run struct {
+	r     *bufio.Reader
+	value int
+	done  bool
+}
+
+func (r *run) advance() error {
+	value, err := binary.ReadVarint(r.r)
+	if err == io.EOF {
+		r.done = true
+		return nil
+	}
+	r.value = int(value)
+	return err
+}
+
+type runHeap []*run
+
+func (h runHeap) Len() int           { return len(h) }
+func (h runHeap) Less(i, j int) bool { return h[i].value < h[j].value }
+func (h runHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *runHeap) Push(x any) {
+	*h = append(*h, x.(*run))
+}
+
+func (h *runHeap) Pop() any {
+	old := *h
+	r := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return r
+}
@@ -0,0 +1,196 @@
+package day1
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadRows reads the lists one line at a time, calling fn with the locations
+// of every line, so that the lists never have to be held in memory. Every
+// line must have [width] locations, or as many as the first line if [width]
+// is 0. Like parse.Lines, it accepts CRLF line endings and trailing blank
+// lines. The row passed to fn must not be kept.
+func ReadRows(r io.Reader, width int, fn func(row []int) error) error {
+	scanner := bufio.NewScanner(r)
+	lineNumber, firstBlank := 0, 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			// A blank line is only an error if more lines follow.
+			if firstBlank == 0 {
+				firstBlank = lineNumber
+			}
+			continue
+		}
+		if firstBlank != 0 {
+			_, err := parseRow("", firstBlank, width)
+			return err
+		}
+
+		row, err := parseRow(line, lineNumber, width)
+		if err != nil {
+			return err
+		}
+		width = len(row)
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// ReadPairs reads lists of exactly two columns, calling fn for every line.
+func ReadPairs(r io.Reader, fn func(left, right int) error) error {
+	return ReadRows(r, 2, func(row []int) error {
+		return fn(row[0], row[1])
+	})
+}
+
+// SimilarityScore computes the similarity of the lists in a single pass, from
+// how many times each location was seen in each list so far. A location of a
+// line is counted with the equal locations of the other list already read,
+// so that every pair of equal locations is counted once, on the later line.
+func SimilarityScore(r io.Reader) (int, error) {
+	leftCounts := make(map[int]int)
+	rightCounts := make(map[int]int)
+	total := 0
+	err := ReadPairs(r, func(left, right int) error {
+		total += left * rightCounts[left]
+		leftCounts[left]++
+		total += right * leftCounts[right]
+		rightCounts[right]++
+		return nil
+	})
+	return total, err
+}
+
+type DistanceOptions struct {
+	// Number of locations of every line, or 0 for as many as the first line.
+	Width int
+	// The two columns whose distance is computed, counted from 0, or nil to
+	// add up the distances of every pair of columns.
+	Columns []int
+	// Distance between two locations, Absolute if nil.
+	Metric Metric
+	Sort   SortOptions
+}
+
+// TotalDistance computes the distance between columns of the lists, sorting
+// every column with a sorter of the given options, so that lists too large
+// for the memory are sorted on disk.
+func TotalDistance(r io.Reader, opts DistanceOptions) (int, error) {
+	if opts.Columns != nil && len(opts.Columns) != 2 {
+		return 0, fmt.Errorf("want 2 columns to compare, got %d", len(opts.Columns))
+	}
+
+	sorters := make([]*Sorter, 0)
+	defer func() { closeSorters(sorters) }()
+	err := ReadRows(r, opts.Width, func(row []int) error {
+		if len(sorters) == 0 {
+			if err := checkColumns(opts.Columns, len(row)); err != nil {
+				return err
+			}
+			for range row {
+				sorters = append(sorters, NewSorter(opts.Sort))
+			}
+		}
+		for _, column := range selectColumns(opts.Columns, len(row)) {
+			if err := sorters[column].Add(row[column]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, pair := range columnPairs(opts.Columns, len(sorters)) {
+		distance, err := sortedDistance(sorters[pair[0]], sorters[pair[1]], opts.Metric)
+		if err != nil {
+			return 0, err
+		}
+		total += distance
+	}
+	return total, nil
+}
+
+func checkColumns(columns []int, width int) error {
+	for _, column := range columns {
+		if column < 0 || column >= width {
+			return fmt.Errorf("column %d does not exist, the lists have %d columns", column, width)
+		}
+	}
+	return nil
+}
+
+// Returns the columns to sort: the chosen ones, once each, or all of them.
+func selectColumns(columns []int, width int) []int {
+	if columns != nil && columns[0] == columns[1] {
+		return columns[:1]
+	}
+	if columns != nil {
+		return columns
+	}
+	all := make([]int, width)
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
+
+// Returns the pairs of columns to compare: the chosen pair, or every pair.
+func columnPairs(columns []int, width int) [][2]int {
+	if columns != nil {
+		if width == 0 {
+			return nil
+		}
+		return [][2]int{{columns[0], columns[1]}}
+	}
+	pairs := make([][2]int, 0)
+	for i := range width {
+		for j := i + 1; j < width; j++ {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+	return pairs
+}
+
+// Adds up the distances of the locations of two sorted columns, taken in
+// order.
+func sortedDistance(left, right *Sorter, metric Metric) (int, error) {
+	if metric == nil {
+		metric = Absolute
+	}
+	lefts, err := left.Sorted()
+	if err != nil {
+		return 0, err
+	}
+	rights, err := right.Sorted()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		l, ok, err := lefts()
+		if err != nil || !ok {
+			return total, err
+		}
+		r, _, err := rights()
+		if err != nil {
+			return 0, err
+		}
+		total += metric(l, r)
+	}
+}
+
+func closeSorters(sorters []*Sorter) {
+	for _, sorter := range sorters {
+		sorter.Close()
+	}
+}
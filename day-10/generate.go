@@ -0,0 +1,44 @@
+package day10
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+)
+
+// Generate returns a map of the given size with random heights, about the
+// given fraction of the cells being impassable, and trails going up from 0 to
+// 9 drawn over it.
+func Generate(rng *rand.Rand, width, height int, impassable float64, trails int) string {
+	heights := grid.New(width, height, '.')
+	for pos := range heights.Positions() {
+		if rng.Float64() >= impassable {
+			heights.Set(pos, rune('0'+rng.IntN(10)))
+		}
+	}
+
+	for range trails {
+		pos := Position{X: rng.IntN(width), Y: rng.IntN(height)}
+		trail := []Position{pos}
+		for h := range 10 {
+			heights.Set(pos, rune('0'+h))
+			// Never back on the trail, which would break it.
+			next := slices.DeleteFunc(heights.Neighbours4(pos), func(p Position) bool {
+				return slices.Contains(trail, p)
+			})
+			if len(next) == 0 {
+				break
+			}
+			pos = next[rng.IntN(len(next))]
+			trail = append(trail, pos)
+		}
+	}
+	return heights.String()
+}
+
+// GenerateInput returns an input of the size of the real ones, which have no
+// impassable cells.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 45, 45, 0, 250)
+}
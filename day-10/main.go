@@ -1,71 +1,30 @@
-package main
+package day10
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
-	"slices"
-	"strconv"
-	"strings"
-)
-
-//go:embed input.txt
-var input string
-
-type Grid struct {
-	Width, Height int
-	Values        [][]int
-}
-
-func (grid Grid) ValueAt(pos Position) int {
-	return grid.Values[pos.Y][pos.X]
-}
-
-type Direction struct {
-	Dx, Dy int
-}
-
-var (
-	UP    = Direction{0, -1}
-	DOWN  = Direction{0, 1}
-	RIGHT = Direction{1, 0}
-	LEFT  = Direction{-1, 0}
 
-	ALL_DIRS = []Direction{UP, RIGHT, DOWN, LEFT}
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parallel"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/search"
 )
 
-type Position struct {
-	X, Y int
-}
+type Position = grid.Position
 
-func (pos Position) MoveAlong(dir Direction) Position {
-	return Position{pos.X + dir.Dx, pos.Y + dir.Dy}
+// The height at each position of the map, or -1 if the position cannot be passed.
+type Grid struct {
+	grid.Grid[int]
 }
 
 func (grid Grid) IdentifyStartingPositions() []Position {
-	positions := make([]Position, 0)
-	for y := 0; y < grid.Height; y++ {
-		for x := 0; x < grid.Width; x++ {
-			if grid.ValueAt(Position{x, y}) == 0 {
-				positions = append(positions, Position{x, y})
-			}
-		}
-	}
-	return positions
-}
-
-func (grid Grid) IsInBounds(pos Position) bool {
-	return (pos.X >= 0 && pos.X < grid.Width) && (pos.Y >= 0 && pos.Y < grid.Height)
+	return grid.FindAll(func(height int) bool { return height == 0 })
 }
 
 func (grid Grid) FindNextPossibleLocations(pos Position) []Position {
 	positions := make([]Position, 0, 4)
-	for _, dir := range ALL_DIRS {
-		nextPos := pos.MoveAlong(dir)
-		if !grid.IsInBounds(nextPos) {
-			continue
-		}
-
-		if grid.ValueAt(nextPos) != grid.ValueAt(pos)+1 {
+	for _, nextPos := range grid.Neighbours4(pos) {
+		if grid.At(nextPos) != grid.At(pos)+1 {
 			continue
 		}
 
@@ -74,79 +33,81 @@ func (grid Grid) FindNextPossibleLocations(pos Position) []Position {
 	return positions
 }
 
-func (grid Grid) FindReachableTops(start Position) int {
-	visited := make([]Position, 0)
-	count := 0
-	for queue := []Position{start}; len(queue) != 0; queue = queue[1:] {
-		pos := queue[0]
-		if !slices.Contains(visited, pos) {
-			visited = append(visited, pos)
-			if grid.Values[pos.Y][pos.X] != 9 {
-				nextPositions := grid.FindNextPossibleLocations(pos)
-				queue = append(queue, nextPositions...)
-			} else {
-				count++
-			}
+// Returns the positions of height 9 reachable from the start.
+func (grid Grid) findTops(result *search.Result[Position]) []Position {
+	tops := make([]Position, 0)
+	for _, pos := range result.Nodes() {
+		if grid.At(pos) == 9 {
+			tops = append(tops, pos)
 		}
 	}
-	return count
+	return tops
 }
 
+func (grid Grid) FindReachableTops(start Position) int {
+	return len(grid.findTops(search.BFS(start, grid.FindNextPossibleLocations)))
+}
+
+// Every trail to a top climbs one height at a time, so all the trails to a
+// top have the same length and are its shortest paths.
 func (grid Grid) FindPossibleTrails(start Position) int {
+	result := search.AllShortestPaths(start, search.Unit(grid.FindNextPossibleLocations))
 	count := 0
-	for queue := []Position{start}; len(queue) != 0; queue = queue[1:] {
-		if grid.ValueAt(queue[0]) != 9 {
-			nextPositions := grid.FindNextPossibleLocations(queue[0])
-			queue = append(queue, nextPositions...)
-		} else {
-			count++
-		}
+	for _, top := range grid.findTops(result) {
+		count += result.CountPaths(top)
 	}
 	return count
 }
 
-func (grid Grid) FindTotalScore(scoreFinder func(Position) int) int {
-	startingPos := grid.IdentifyStartingPositions()
-
-	scoreCh := make(chan int, len(startingPos))
-	for _, pos := range startingPos {
-		go func() {
-			scoreCh <- scoreFinder(pos)
-		}()
+// Adds up the scores of the trailheads, found in parallel.
+func (grid Grid) FindTotalScore(ctx context.Context, scoreFinder func(Position) int) (int, error) {
+	scores, err := parallel.Map(ctx, grid.IdentifyStartingPositions(), func(ctx context.Context, pos Position) (int, error) {
+		return scoreFinder(pos), nil
+	})
+	if err != nil {
+		return 0, err
 	}
 
 	total := 0
-	for i := 0; i < len(startingPos); i++ {
-		total += <-scoreCh
+	for _, score := range scores {
+		total += score
 	}
-	return total
+	return total, nil
 }
 
-func ReadInput(input string) Grid {
-	lines := strings.Split(input, "\n")
-	grid := Grid{
-		Height: len(lines),
-		Width:  len(lines[0]),
-		Values: make([][]int, len(lines)),
-	}
-	for i, line := range lines {
-		row := make([]int, len(line))
-		for j := range line {
-			v, err := strconv.ParseInt(line[j:j+1], 10, 8)
-			if err != nil {
-				row[j] = -1
-			} else {
-				row[j] = int(v)
-			}
-		}
-		grid.Values[i] = row
+// Positions that cannot be passed are marked by '.'.
+func parseHeight(char rune) (int, error) {
+	switch {
+	case char == '.':
+		return -1, nil
+	case char >= '0' && char <= '9':
+		return int(char - '0'), nil
 	}
+	return 0, fmt.Errorf("unexpected %q, want a height from 0 to 9 or '.'", char)
+}
 
-	return grid
+func ReadInput(input string) (Grid, error) {
+	heights, err := grid.Parse(input, parseHeight)
+	return Grid{heights}, err
 }
 
-func main() {
-	grid := ReadInput(input)
-	fmt.Println("Part 1 Solution:", grid.FindTotalScore(grid.FindReachableTops))
-	fmt.Println("Part 2 Solution:", grid.FindTotalScore(grid.FindPossibleTrails))
+func init() {
+	registry.Register(registry.Day{
+		Number: 10,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			grid, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return grid.FindTotalScore(ctx, grid.FindReachableTops)
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			grid, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return grid.FindTotalScore(ctx, grid.FindPossibleTrails)
+		},
+		Generate: GenerateInput,
+	})
 }
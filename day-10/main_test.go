@@ -1,9 +1,15 @@
-package main
+package day10
 
 import (
+	"context"
+	"math/rand/v2"
 	"reflect"
 	"slices"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
 )
 
 var part1TestInput = `89010123
@@ -15,6 +21,15 @@ var part1TestInput = `89010123
 01329801
 10456732`
 
+func mustReadInput(t *testing.T, input string) Grid {
+	t.Helper()
+	grid, err := ReadInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	return grid
+}
+
 func TestReadGrid(t *testing.T) {
 	testcases := []struct {
 		Name  string
@@ -27,22 +42,21 @@ func TestReadGrid(t *testing.T) {
 1234
 8765
 9876`,
-			Want: Grid{
-				Height: 4,
-				Width:  4,
-				Values: [][]int{
-					{0, 1, 2, 3},
-					{1, 2, 3, 4},
-					{8, 7, 6, 5},
-					{9, 8, 7, 6},
-				},
-			},
+			Want: Grid{grid.FromCells([][]int{
+				{0, 1, 2, 3},
+				{1, 2, 3, 4},
+				{8, 7, 6, 5},
+				{9, 8, 7, 6},
+			})},
 		},
 	}
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			got := ReadInput(testcase.Input)
+			got, err := ReadInput(testcase.Input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
 			if !reflect.DeepEqual(got, testcase.Want) {
 				t.Errorf("Got wrong output: got %v, want %v", got, testcase.Want)
 			}
@@ -58,8 +72,8 @@ func TestIdentifStartingPoints(t *testing.T) {
 	}{
 		{
 			Name:  "read example grid",
-			Input: ReadInput("0123\n1234\n8765\n9876"),
-			Want:  []Position{{0, 0}},
+			Input: mustReadInput(t, "0123\n1234\n8765\n9876"),
+			Want:  []Position{{X: 0, Y: 0}},
 		},
 	}
 
@@ -99,21 +113,21 @@ func TestFindNextPossibleLocations(t *testing.T) {
 	}{
 		{
 			Name:  "Can move either up or right at (0,0)",
-			Input: ReadInput("0123\n1234\n8765\n9876"),
-			Pos:   Position{0, 0},
-			Want:  []Position{{0, 1}, {1, 0}},
+			Input: mustReadInput(t, "0123\n1234\n8765\n9876"),
+			Pos:   Position{X: 0, Y: 0},
+			Want:  []Position{{X: 0, Y: 1}, {X: 1, Y: 0}},
 		},
 		{
 			Name:  "Can move either up or right at (1,0)",
-			Input: ReadInput("0123\n1234\n8765\n9876"),
-			Pos:   Position{1, 0},
-			Want:  []Position{{2, 0}, {1, 1}},
+			Input: mustReadInput(t, "0123\n1234\n8765\n9876"),
+			Pos:   Position{X: 1, Y: 0},
+			Want:  []Position{{X: 2, Y: 0}, {X: 1, Y: 1}},
 		},
 		{
 			Name:  "Can move only UP at (4,2)",
-			Input: ReadInput(part1TestInput),
-			Pos:   Position{4, 2},
-			Want:  []Position{{4, 1}},
+			Input: mustReadInput(t, part1TestInput),
+			Pos:   Position{X: 4, Y: 2},
+			Want:  []Position{{X: 4, Y: 1}},
 		},
 	}
 
@@ -134,32 +148,32 @@ func TestFindReachableTops(t *testing.T) {
 	}{
 		{
 			Name:  "Can reach one 9 from (0,0)",
-			Input: ReadInput("0123\n1234\n8765\n9876"),
-			Start: Position{0, 0},
+			Input: mustReadInput(t, "0123\n1234\n8765\n9876"),
+			Start: Position{X: 0, Y: 0},
 			Want:  1,
 		},
 		{
 			Name:  "Can move to 5 9s from (2,0)",
-			Input: ReadInput(part1TestInput),
-			Start: Position{2, 0},
+			Input: mustReadInput(t, part1TestInput),
+			Start: Position{X: 2, Y: 0},
 			Want:  5,
 		},
 		{
 			Name:  "Can move to 6 9s from (2,0)",
-			Input: ReadInput(part1TestInput),
-			Start: Position{4, 0},
+			Input: mustReadInput(t, part1TestInput),
+			Start: Position{X: 4, Y: 0},
 			Want:  6,
 		},
 		{
 			Name: "Example input",
-			Input: ReadInput(`...0...
+			Input: mustReadInput(t, `...0...
 ...1...
 ...2...
 6543456
 7.....7
 8.....8
 9.....9`),
-			Start: Position{4, 0},
+			Start: Position{X: 4, Y: 0},
 			Want:  2,
 		},
 	}
@@ -175,19 +189,80 @@ func TestFindReachableTops(t *testing.T) {
 }
 
 func TestPart1Solution(t *testing.T) {
-	grid := ReadInput(part1TestInput)
+	grid := mustReadInput(t, part1TestInput)
 	want := 36
-	got := grid.FindTotalScore(grid.FindReachableTops)
+	got, err := grid.FindTotalScore(context.Background(), grid.FindReachableTops)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	if got != want {
 		t.Errorf("Got wrong output: got %d, want %d", got, want)
 	}
 }
 
 func TestPart2Solution(t *testing.T) {
-	grid := ReadInput(part1TestInput)
+	grid := mustReadInput(t, part1TestInput)
 	want := 81
-	got := grid.FindTotalScore(grid.FindPossibleTrails)
+	got, err := grid.FindTotalScore(context.Background(), grid.FindPossibleTrails)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	if got != want {
 		t.Errorf("Got wrong output: got %d, want %d", got, want)
 	}
 }
+
+func TestReadInputErrors(t *testing.T) {
+	_, err := ReadInput("0123\n1x34")
+	if want := `line 2, column 2: unexpected 'x', want a height from 0 to 9 or '.'`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+// Follows every trail from every trailhead, one step at a time, and returns
+// the total score and rating.
+func bruteForce(heights Grid) (int, int) {
+	var climb func(pos Position, tops map[Position]bool) int
+	climb = func(pos Position, tops map[Position]bool) int {
+		if heights.At(pos) == 9 {
+			tops[pos] = true
+			return 1
+		}
+		trails := 0
+		for _, next := range heights.Neighbours4(pos) {
+			if heights.At(next) == heights.At(pos)+1 {
+				trails += climb(next, tops)
+			}
+		}
+		return trails
+	}
+
+	score, rating := 0, 0
+	for pos, height := range heights.All() {
+		if height == 0 {
+			tops := map[Position]bool{}
+			rating += climb(pos, tops)
+			score += len(tops)
+		}
+	}
+	return score, rating
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(12), 1+rng.IntN(12), 0.25, rng.IntN(6)) }
+	proptest.CheckParts(t, 10, generate, func(input string) (any, any) {
+		heights, err := ReadInput(input)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		return bruteForce(heights)
+	})
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 10, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 10, 2)
+}
@@ -0,0 +1,30 @@
+package day11
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Generate returns the given number of stones, engraved with numbers of 1 to
+// 7 digits, one of them being 0 if there are at least two stones.
+func Generate(rng *rand.Rand, stones int) string {
+	values := make([]string, stones)
+	for i := range values {
+		digits := 1 + rng.IntN(7)
+		low := 1
+		for range digits - 1 {
+			low *= 10
+		}
+		values[i] = strconv.Itoa(low + rng.IntN(9*low))
+	}
+	if stones > 1 {
+		values[rng.IntN(stones)] = "0"
+	}
+	return strings.Join(values, " ") + "\n"
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 8)
+}
@@ -1,22 +1,31 @@
-package main
+package day11
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
 	"math"
-	"strconv"
-	"strings"
-)
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/memo"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parallel"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
 type BlinkInfo struct {
 	Value  int64
 	Blinks int
 }
 
-var BlinkCache = map[BlinkInfo]int{}
+// The number of stones a stone turns into after some blinks, shared by the
+// goroutines counting the stones.
+type BlinkCache = memo.Memo[BlinkInfo, int]
+
+func NewBlinkCache() *BlinkCache {
+	return memo.New[BlinkInfo, int](memo.Options[BlinkInfo]{
+		Shards: 16,
+		Hash:   func(key BlinkInfo) uint64 { return memo.HashInts(key.Value, int64(key.Blinks)) },
+	})
+}
 
 func findNumDigits(num int64) int {
 	return len(fmt.Sprint(num))
@@ -37,21 +46,21 @@ func ApplyBlinkRule(value int64) []int64 {
 	return []int64{value / int64(exp), value % int64(exp)}
 }
 
-func GetCountAfterBlinks(value int64, blinks int) int {
+func GetCountAfterBlinks(cache *BlinkCache, value int64, blinks int) int {
 	if blinks == 0 {
 		return 1
 	}
-	score := GetTotalElementsAfterBlinks(ApplyBlinkRule(value), blinks-1)
+	score := GetTotalElementsAfterBlinks(cache, ApplyBlinkRule(value), blinks-1)
 	return score
 }
 
-func GetTotalElementsAfterBlinks(values []int64, blinks int) int {
+func GetTotalElementsAfterBlinks(cache *BlinkCache, values []int64, blinks int) int {
 	total := 0
 	for _, value := range values {
-		score, ok := BlinkCache[BlinkInfo{value, blinks}]
+		score, ok := cache.Get(BlinkInfo{value, blinks})
 		if !ok {
-			score = GetCountAfterBlinks(value, blinks)
-			BlinkCache[BlinkInfo{value, blinks}] = score
+			score = GetCountAfterBlinks(cache, value, blinks)
+			cache.Set(BlinkInfo{value, blinks}, score)
 		}
 
 		total += score
@@ -59,17 +68,60 @@ func GetTotalElementsAfterBlinks(values []int64, blinks int) int {
 	return total
 }
 
-func ReadInput(input string) []int64 {
-	numTexts := strings.Split(input, " ")
-	values := make([]int64, len(numTexts))
-	for i, numText := range numTexts {
-		values[i], _ = strconv.ParseInt(numText, 10, 64)
+// Counts the stones of every starting stone in parallel, and reports how well
+// the cache they share did.
+func CountStones(ctx context.Context, values []int64, blinks int) (int, error) {
+	cache := NewBlinkCache()
+	counts, err := parallel.Map(ctx, values, func(ctx context.Context, value int64) (int, error) {
+		return GetCountAfterBlinks(cache, value, blinks), nil
+	})
+	memo.Report(ctx, "blinks", cache.Stats())
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	return total, nil
+}
+
+func ReadInput(input string) ([]int64, error) {
+	lines := parse.Lines(input)
+	if len(lines) != 1 {
+		return nil, fmt.Errorf("want the stones on a single line, got %d lines", len(lines))
+	}
+
+	values, err := parse.Ints(lines[0], 1)
+	if err != nil {
+		return nil, err
+	}
+
+	stones := make([]int64, len(values))
+	for i, value := range values {
+		stones[i] = int64(value)
 	}
-	return values
+	return stones, nil
 }
 
-func main() {
-	values := ReadInput(input)
-	fmt.Println("Part 1 solution:", GetTotalElementsAfterBlinks(values, 25))
-	fmt.Println("Part 2 solution:", GetTotalElementsAfterBlinks(values, 75))
+func init() {
+	registry.Register(registry.Day{
+		Number: 11,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			values, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return CountStones(ctx, values, 25)
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			values, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return CountStones(ctx, values, 75)
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,9 +1,12 @@
-package main
+package day11
 
 import (
-	"fmt"
+	"context"
 	"reflect"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/memo"
 )
 
 func TestApplyBlinkRule(t *testing.T) {
@@ -89,7 +92,7 @@ func TestGetCountAfterBlinks(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			got := GetCountAfterBlinks(int64(testcase.Value), testcase.Blinks)
+			got := GetCountAfterBlinks(NewBlinkCache(), int64(testcase.Value), testcase.Blinks)
 			if got != testcase.Want {
 				t.Errorf("Got wrong output: got %d, want %d", got, testcase.Want)
 			}
@@ -100,13 +103,60 @@ func TestGetCountAfterBlinks(t *testing.T) {
 func TestTotalCount(t *testing.T) {
 	input := []int64{125, 17}
 	want := 55312
-	got := GetTotalElementsAfterBlinks(input, 25)
-	fmt.Println(got, want)
+	got := GetTotalElementsAfterBlinks(NewBlinkCache(), input, 25)
+	if got != want {
+		t.Errorf("Got wrong output: got %d, want %d", got, want)
+	}
+}
+
+func TestCountStonesReportsTheCache(t *testing.T) {
+	collector := &memo.Collector{}
+	ctx := memo.NewContext(context.Background(), collector)
+
+	got, err := CountStones(ctx, []int64{125, 17}, 25)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got != 55312 {
+		t.Errorf("Got wrong output: got %d, want 55312", got)
+	}
+
+	stats := collector.Stats()
+	if len(stats) != 1 || stats[0].Name != "blinks" || stats[0].Hits == 0 || stats[0].Entries == 0 {
+		t.Errorf("Got wrong cache statistics: %+v", stats)
+	}
 }
 
 func BenchmarkGetTotalElementsAfterBlinks(b *testing.B) {
-	values := ReadInput(input)
+	input := testutil.LoadOrSkip(b, 11)
+	values, err := ReadInput(input)
+	if err != nil {
+		b.Fatal(err)
+	}
 	for i := 0; i < b.N; i++ {
-		GetTotalElementsAfterBlinks(values, 25)
+		GetTotalElementsAfterBlinks(NewBlinkCache(), values, 25)
 	}
 }
+
+func TestReadInput(t *testing.T) {
+	got, err := ReadInput("125 17\n")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if want := []int64{125, 17}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong output: got %v, want %v", got, want)
+	}
+
+	_, err = ReadInput("125 1x7")
+	if want := `line 1, column 5: invalid number "1x7"`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 11, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 11, 2)
+}
@@ -0,0 +1,33 @@
+package day12
+
+import (
+	"math/rand/v2"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+)
+
+// Generate returns a garden of the given size with the given number of kinds
+// of plants, from A. The plants are grown from random seeds, so that they
+// form regions of various shapes, with holes and regions of the same plant
+// touching at a corner.
+func Generate(rng *rand.Rand, width, height, plants int) string {
+	garden := grid.New(width, height, '.')
+	for pos := range garden.Positions() {
+		garden.Set(pos, rune('A'+rng.IntN(plants)))
+	}
+
+	// Copy the plant of a neighbour to make the regions grow.
+	for range width * height * 2 {
+		pos := Position{X: rng.IntN(width), Y: rng.IntN(height)}
+		neighbours := garden.Neighbours4(pos)
+		if len(neighbours) > 0 {
+			garden.Set(pos, garden.At(neighbours[rng.IntN(len(neighbours))]))
+		}
+	}
+	return garden.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 140, 140, 26)
+}
@@ -1,60 +1,29 @@
-package main
+package day12
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
 	"slices"
-	"strings"
-)
-
-//go:embed input.txt
-var input string
-
-type Grid struct {
-	Width, Height int
-	Values        [][]rune
-}
-
-func (grid Grid) At(pos Position) rune {
-	return grid.Values[pos.Y][pos.X]
-}
-
-func (grid Grid) IsInBounds(pos Position) bool {
-	return pos.X >= 0 && pos.X < grid.Width && pos.Y >= 0 && pos.Y < grid.Height
-}
-
-type Direction struct {
-	Dx, Dy int
-}
-
-func (dir Direction) FindPerpDirs() [2]Direction {
-	if dir == UP || dir == DOWN {
-		return [2]Direction{RIGHT, LEFT}
-	}
-
-	return [2]Direction{UP, DOWN}
-}
 
-var (
-	UP    = Direction{0, -1}
-	DOWN  = Direction{0, 1}
-	RIGHT = Direction{1, 0}
-	LEFT  = Direction{-1, 0}
-
-	ALL_DIRS = []Direction{UP, RIGHT, DOWN, LEFT}
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/search"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
 )
 
-type Position struct {
-	X, Y int
-}
+type (
+	Direction = grid.Direction
+	Position  = grid.Position
+)
 
-func (pos Position) MoveAlong(dir Direction) Position {
-	return Position{pos.X + dir.Dx, pos.Y + dir.Dy}
+type Grid struct {
+	grid.Grid[rune]
 }
 
 func FindAdjacentValidCells(pos Position, isValid func(pos Position) bool) []Position {
 	positions := make([]Position, 0, 4)
-	for _, dir := range ALL_DIRS {
+	for _, dir := range grid.ALL_DIRS {
 		nextPos := pos.MoveAlong(dir)
 		if isValid(nextPos) {
 			positions = append(positions, nextPos)
@@ -65,7 +34,7 @@ func FindAdjacentValidCells(pos Position, isValid func(pos Position) bool) []Pos
 
 func FindAdjacentCells(pos Position) []PosDir {
 	positions := make([]PosDir, 0, 4)
-	for _, dir := range ALL_DIRS {
+	for _, dir := range grid.ALL_DIRS {
 		nextPos := pos.MoveAlong(dir)
 		positions = append(positions, PosDir{nextPos, dir})
 	}
@@ -101,20 +70,16 @@ type PosDir struct {
 
 func (region Region) NumSides(grid Grid) int {
 	total := 0
-	posDirs := make([]PosDir, 0)
 	for _, pos := range region {
 		for _, adjPos := range FindAdjacentCells(pos) {
-			if slices.Contains(region, adjPos.Pos) {
+			if region.Has(adjPos.Pos) {
 				continue
 			}
 
-			posDirs = append(posDirs, adjPos)
-
-			perpDirs := adjPos.Dir.FindPerpDirs()
-			if slices.Contains(posDirs, PosDir{adjPos.Pos.MoveAlong(perpDirs[0]), adjPos.Dir}) {
-				continue
-			}
-			if slices.Contains(posDirs, PosDir{adjPos.Pos.MoveAlong(perpDirs[1]), adjPos.Dir}) {
+			// Count each side once, at its last cell. The side goes on if the
+			// next cell along it is also on the edge of the region.
+			along := adjPos.Dir.Perpendicular()[0]
+			if region.Has(pos.MoveAlong(along)) && !region.Has(adjPos.Pos.MoveAlong(along)) {
 				continue
 			}
 
@@ -129,33 +94,30 @@ func (region Region) Has(pos Position) bool {
 }
 
 func (grid Grid) FindContainingRegion(pos Position) Region {
-	region := Region{pos}
-
-	isValidNextPos := func(nextPos Position) bool {
-		return grid.IsInBounds(nextPos) && grid.At(pos) == grid.At(nextPos) && !region.Has(nextPos)
+	plant := grid.At(pos)
+	isSamePlant := func(nextPos Position) bool {
+		return grid.IsInBounds(nextPos) && grid.At(nextPos) == plant
 	}
 
-	for queue := []Position{pos}; len(queue) > 0; queue = queue[1:] {
-		nextPositions := FindAdjacentValidCells(queue[0], isValidNextPos)
-		queue = append(queue, nextPositions...)
-		region = append(region, nextPositions...)
-	}
-	return region
+	result := search.BFS(pos, func(pos Position) []Position {
+		return FindAdjacentValidCells(pos, isSamePlant)
+	})
+	return Region(result.Nodes())
 }
 
 func (grid Grid) FindTotalScore(scoreFn func(grid Grid, region Region) int) int {
-	visited := make([]Position, 0)
+	visited := make(map[Position]bool)
 
 	total := 0
-	for x := 0; x < grid.Width; x++ {
-		for y := 0; y < grid.Height; y++ {
-			if slices.Contains(visited, Position{x, y}) {
-				continue
-			}
+	for pos := range grid.Positions() {
+		if visited[pos] {
+			continue
+		}
 
-			region := grid.FindContainingRegion(Position{x, y})
-			total += scoreFn(grid, region)
-			visited = append(visited, region...)
+		region := grid.FindContainingRegion(pos)
+		total += scoreFn(grid, region)
+		for _, cell := range region {
+			visited[cell] = true
 		}
 	}
 	return total
@@ -173,22 +135,66 @@ func (grid Grid) SolveForPart2() int {
 	})
 }
 
-func ReadInput(input string) Grid {
-	lines := strings.Split(input, "\n")
-	grid := Grid{
-		Width:  len(lines[0]),
-		Height: len(lines),
-		Values: make([][]rune, len(lines)),
+// Every plant is marked by an uppercase letter.
+func parsePlant(char rune) (rune, error) {
+	if char < 'A' || char > 'Z' {
+		return 0, fmt.Errorf("unexpected %q, want a plant from A to Z", char)
+	}
+	return char, nil
+}
+
+func ReadInput(input string) (Grid, error) {
+	plants, err := grid.Parse(input, parsePlant)
+	return Grid{plants}, err
+}
+
+// Colours the garden one region at a time, in the order they are found. The
+// cells of the regions not found yet are '.'.
+func Animate(ctx context.Context, input string, observer trace.Observer[grid.Grid[rune]]) error {
+	garden, err := ReadInput(input)
+	if err != nil {
+		return err
 	}
 
-	for i, line := range lines {
-		grid.Values[i] = []rune(line)
+	frame := grid.New(garden.Width, garden.Height, '.')
+	step := 0
+	for pos, plant := range garden.All() {
+		if frame.At(pos) != '.' {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		region := garden.FindContainingRegion(pos)
+		for _, cell := range region {
+			frame.Set(cell, plant)
+		}
+		action := fmt.Sprintf("region of %c with area %d", plant, region.Area())
+		observer.Observe(trace.Step[grid.Grid[rune]]{Index: step, Action: action, State: frame.Copy()})
+		step++
 	}
-	return grid
+	return nil
 }
 
-func main() {
-	grid := ReadInput(input)
-	fmt.Println("Part 1 Solution:", grid.SolveForPart1())
-	fmt.Println("Part 2 Solution:", grid.SolveForPart2())
+func init() {
+	registry.Register(registry.Day{
+		Number: 12,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			grid, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return grid.SolveForPart1(), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			grid, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return grid.SolveForPart2(), nil
+		},
+		Generate: GenerateInput,
+	})
+	animate.Register(12, Animate)
 }
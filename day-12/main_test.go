@@ -1,20 +1,19 @@
-package main
+package day12
 
 import (
+	"context"
+	"math/rand/v2"
+	"reflect"
 	"slices"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
 )
 
-var testInput = `RRRRIICCFF
-RRRRIICCCF
-VVRRRCCFFF
-VVRCCCJFFF
-VVVVCJJCFE
-VVIVCCJJEE
-VVIIICJJEE
-MIIIIIJJEE
-MIIISIJEEE
-MMMISSJEEE`
+var testInput = testutil.MustReadExample("example-1.txt")
 
 func CheckIfElementsAreSame[T comparable](t *testing.T, got, want []T) {
 	t.Helper()
@@ -33,8 +32,17 @@ func CheckIfElementsAreSame[T comparable](t *testing.T, got, want []T) {
 	}
 }
 
+func mustReadInput(t *testing.T, input string) Grid {
+	t.Helper()
+	grid, err := ReadInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	return grid
+}
+
 func TestFindRegion(t *testing.T) {
-	grid := ReadInput(testInput)
+	grid := mustReadInput(t, testInput)
 	testcases := []struct {
 		Name  string
 		Input Grid
@@ -44,49 +52,49 @@ func TestFindRegion(t *testing.T) {
 		{
 			Name:  "region with only single point if no adjacent places",
 			Input: grid,
-			Pos:   Position{7, 4},
-			Want:  Region{{7, 4}},
+			Pos:   Position{X: 7, Y: 4},
+			Want:  Region{{X: 7, Y: 4}},
 		},
 		{
 			Name:  "select 2 horizontally adjacent cells with same plant",
-			Input: Grid{Height: 2, Width: 3, Values: [][]rune{{'A', 'A', 'B'}, {'B', 'B', 'C'}}},
-			Pos:   Position{0, 0},
-			Want:  Region{{0, 0}, {1, 0}},
+			Input: mustReadInput(t, "AAB\nBBC"),
+			Pos:   Position{X: 0, Y: 0},
+			Want:  Region{{X: 0, Y: 0}, {X: 1, Y: 0}},
 		},
 		{
 			Name:  "select 3 horizontally adjacent cells with same plant",
-			Input: Grid{Height: 2, Width: 3, Values: [][]rune{{'A', 'A', 'A'}, {'B', 'B', 'C'}}},
-			Pos:   Position{0, 0},
-			Want:  Region{{0, 0}, {1, 0}, {2, 0}},
+			Input: mustReadInput(t, "AAA\nBBC"),
+			Pos:   Position{X: 0, Y: 0},
+			Want:  Region{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}},
 		},
 		{
 			Name:  "also need to move along towards left",
-			Input: Grid{Height: 2, Width: 3, Values: [][]rune{{'A', 'A', 'A'}, {'B', 'B', 'C'}}},
-			Pos:   Position{1, 0},
-			Want:  Region{{0, 0}, {1, 0}, {2, 0}},
+			Input: mustReadInput(t, "AAA\nBBC"),
+			Pos:   Position{X: 1, Y: 0},
+			Want:  Region{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}},
 		},
 		{
 			Name:  "also need to move along towards DOWN",
-			Input: Grid{Height: 3, Width: 2, Values: [][]rune{{'A', 'B'}, {'A', 'B'}, {'A', 'C'}}},
-			Pos:   Position{0, 0},
-			Want:  Region{{0, 0}, {0, 1}, {0, 2}},
+			Input: mustReadInput(t, "AB\nAB\nAC"),
+			Pos:   Position{X: 0, Y: 0},
+			Want:  Region{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}},
 		},
 		{
 			Name:  "also need to move along towards UP",
-			Input: Grid{Height: 3, Width: 2, Values: [][]rune{{'A', 'B'}, {'A', 'B'}, {'A', 'C'}}},
-			Pos:   Position{0, 1},
-			Want:  Region{{0, 0}, {0, 1}, {0, 2}},
+			Input: mustReadInput(t, "AB\nAB\nAC"),
+			Pos:   Position{X: 0, Y: 1},
+			Want:  Region{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}},
 		},
 		{
 			Name:  "a region in given example",
 			Input: grid,
-			Pos:   Position{8, 0},
+			Pos:   Position{X: 8, Y: 0},
 			Want: Region{
-				{8, 0}, {9, 0},
-				{9, 1},
-				{9, 2}, {8, 2}, {7, 2},
-				{9, 3}, {8, 3}, {7, 3},
-				{8, 4},
+				{X: 8, Y: 0}, {X: 9, Y: 0},
+				{X: 9, Y: 1},
+				{X: 9, Y: 2}, {X: 8, Y: 2}, {X: 7, Y: 2},
+				{X: 9, Y: 3}, {X: 8, Y: 3}, {X: 7, Y: 3},
+				{X: 8, Y: 4},
 			},
 		},
 	}
@@ -100,7 +108,7 @@ func TestFindRegion(t *testing.T) {
 }
 
 func TestRegionSides(t *testing.T) {
-	grid := ReadInput(testInput)
+	grid := mustReadInput(t, testInput)
 	testcases := []struct {
 		Name      string
 		Input     Region
@@ -108,19 +116,31 @@ func TestRegionSides(t *testing.T) {
 	}{
 		{
 			Name:      "region with only single point has 4 sides",
-			Input:     Region{{7, 4}},
+			Input:     Region{{X: 7, Y: 4}},
 			WantSides: 4,
 		},
 		{
 			Name:      "region with 2 adjacent points also has 4 sides",
-			Input:     Region{{7, 4}, {7, 5}},
+			Input:     Region{{X: 7, Y: 4}, {X: 7, Y: 5}},
 			WantSides: 4,
 		},
 		{
 			Name:      "region in L shape has 6 sides",
-			Input:     Region{{0, 0}, {1, 0}, {0, 1}},
+			Input:     Region{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}},
 			WantSides: 6,
 		},
+		{
+			// The sides used to be joined only to the cells listed before
+			// them, so a side was counted twice when its middle came last.
+			Name:      "region in a row listed ends first has 4 sides",
+			Input:     Region{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 0}},
+			WantSides: 4,
+		},
+		{
+			Name:      "region in U shape listed out of order has 8 sides",
+			Input:     Region{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 0, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 2}},
+			WantSides: 8,
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -135,7 +155,7 @@ func TestRegionSides(t *testing.T) {
 }
 
 func TestRegionAreaAndPerimeter(t *testing.T) {
-	grid := ReadInput(testInput)
+	grid := mustReadInput(t, testInput)
 	testcases := []struct {
 		Name          string
 		Input         Region
@@ -144,13 +164,13 @@ func TestRegionAreaAndPerimeter(t *testing.T) {
 	}{
 		{
 			Name:          "region with only single point",
-			Input:         grid.FindContainingRegion(Position{7, 4}),
+			Input:         grid.FindContainingRegion(Position{X: 7, Y: 4}),
 			WantArea:      1,
 			WantPerimeter: 4,
 		},
 		{
 			Name:          "example region from given",
-			Input:         grid.FindContainingRegion(Position{0, 0}),
+			Input:         grid.FindContainingRegion(Position{X: 0, Y: 0}),
 			WantArea:      12,
 			WantPerimeter: 18,
 		},
@@ -172,7 +192,7 @@ func TestRegionAreaAndPerimeter(t *testing.T) {
 }
 
 func TestPart1Solution(t *testing.T) {
-	grid := ReadInput(testInput)
+	grid := mustReadInput(t, testInput)
 	want := 1930
 	got := grid.SolveForPart1()
 	if got != want {
@@ -181,10 +201,107 @@ func TestPart1Solution(t *testing.T) {
 }
 
 func TestPart2Solution(t *testing.T) {
-	grid := ReadInput(testInput)
+	grid := mustReadInput(t, testInput)
 	want := 1206
 	got := grid.SolveForPart2()
 	if got != want {
 		t.Errorf("Got wrong solution: got %d, want %d", got, want)
 	}
 }
+
+func TestReadInputErrors(t *testing.T) {
+	_, err := ReadInput("AAB\nB.C")
+	if want := `line 2, column 2: unexpected '.', want a plant from A to Z`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func TestAnimate(t *testing.T) {
+	recorder := &trace.Recorder[grid.Grid[rune]]{}
+	if err := Animate(context.Background(), "AAB\nCAB", recorder); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	wantActions := []string{"region of A with area 3", "region of B with area 2", "region of C with area 1"}
+	if got := recorder.Actions(); !reflect.DeepEqual(got, wantActions) {
+		t.Errorf("Got wrong actions: got %v, want %v", got, wantActions)
+	}
+	if got := recorder.Steps[0].State.String(); got != "AA.\n.A.\n" {
+		t.Errorf("Got wrong first frame:\n%s", got)
+	}
+}
+
+// Returns the total prices of the garden, using the number of corners of a
+// region as its number of sides.
+func bruteForce(garden Grid) (int, int) {
+	regions := grid.New(garden.Width, garden.Height, -1)
+	var fill func(pos Position, region int)
+	fill = func(pos Position, region int) {
+		regions.Set(pos, region)
+		for _, next := range garden.Neighbours4(pos) {
+			if regions.At(next) == -1 && garden.At(next) == garden.At(pos) {
+				fill(next, region)
+			}
+		}
+	}
+
+	count := 0
+	for pos := range garden.Positions() {
+		if regions.At(pos) == -1 {
+			fill(pos, count)
+			count++
+		}
+	}
+
+	inRegion := func(pos Position, region int) bool {
+		return regions.IsInBounds(pos) && regions.At(pos) == region
+	}
+	areas := make([]int, count)
+	perimeters := make([]int, count)
+	corners := make([]int, count)
+	for pos, region := range regions.All() {
+		areas[region]++
+		for _, dir := range grid.ALL_DIRS {
+			if !inRegion(pos.MoveAlong(dir), region) {
+				perimeters[region]++
+			}
+
+			// A corner is between this direction and the next one clockwise.
+			side1, side2 := inRegion(pos.MoveAlong(dir), region), inRegion(pos.MoveAlong(dir.Rotate90()), region)
+			diagonal := inRegion(pos.MoveAlong(dir).MoveAlong(dir.Rotate90()), region)
+			if (!side1 && !side2) || (side1 && side2 && !diagonal) {
+				corners[region]++
+			}
+		}
+	}
+
+	part1, part2 := 0, 0
+	for region := range count {
+		part1 += areas[region] * perimeters[region]
+		part2 += areas[region] * corners[region]
+	}
+	return part1, part2
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(10), 1+rng.IntN(10), 1+rng.IntN(4)) }
+	proptest.CheckParts(t, 12, generate, func(input string) (any, any) {
+		garden, err := ReadInput(input)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		return bruteForce(garden)
+	})
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 12, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 12, 2)
+}
+
+func TestExamples(t *testing.T) {
+	testutil.Examples(t, 12)
+}
@@ -0,0 +1,85 @@
+package day13
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+)
+
+const part2Offset = 10000000000000
+
+// Generate returns the given number of machines, with buttons moving the claw
+// by 10 to 99 along each axis. As in the puzzle, the buttons of a machine are
+// never parallel. A third of the prizes can be won in part 1, another third
+// only once moved for part 2, and the others are random. No prize is won with
+// a negative number of presses, in either part.
+func Generate(rng *rand.Rand, machines int) string {
+	blocks := make([]string, machines)
+	for i := range blocks {
+		info := generateMachine(rng, i%3)
+		for isWonBackwards(info) || isWonBackwards(info.CalibirateForPart2()) {
+			info = generateMachine(rng, i%3)
+		}
+		blocks[i] = fmt.Sprintf("Button A: X+%d, Y+%d\nButton B: X+%d, Y+%d\nPrize: X=%d, Y=%d\n",
+			info.A.X, info.A.Y, info.B.X, info.B.Y, info.Prize.X, info.Prize.Y)
+	}
+	return strings.Join(blocks, "\n")
+}
+
+func generateMachine(rng *rand.Rand, kind int) MachineInfo {
+	info := MachineInfo{A: randomButton(rng), B: randomButton(rng)}
+	for isAlmostParallel(info.A, info.B) {
+		info.B = randomButton(rng)
+	}
+
+	switch kind {
+	case 0:
+		a, b := 1+rng.IntN(100), 1+rng.IntN(100)
+		info.Prize = Vector{a*info.A.X + b*info.B.X, a*info.A.Y + b*info.B.Y}
+	case 1:
+		info.Prize = farPrize(rng, info)
+	default:
+		info.Prize = Vector{1000 + rng.IntN(19000), 1000 + rng.IntN(19000)}
+	}
+	return info
+}
+
+func randomButton(rng *rand.Rand) Vector {
+	return Vector{10 + rng.IntN(90), 10 + rng.IntN(90)}
+}
+
+// Reports whether the solver would take the buttons for parallel ones.
+func isAlmostParallel(a, b Vector) bool {
+	return math.Abs(float64(a.X)/float64(b.X)-float64(a.Y)/float64(b.Y)) < 0.01
+}
+
+// Returns the number of presses of each button to reach the prize, if whole.
+func presses(info MachineInfo) (a, b int, whole bool) {
+	den := info.B.X*info.A.Y - info.B.Y*info.A.X
+	aNum := info.Prize.Y*info.B.X - info.Prize.X*info.B.Y
+	bNum := info.Prize.X*info.A.Y - info.Prize.Y*info.A.X
+	return aNum / den, bNum / den, aNum%den == 0 && bNum%den == 0
+}
+
+func isWonBackwards(info MachineInfo) bool {
+	a, b, whole := presses(info)
+	return whole && (a < 0 || b < 0)
+}
+
+// Returns a prize that can be won once moved for part 2, if the buttons can
+// reach that far, or a random prize if they cannot.
+func farPrize(rng *rand.Rand, info MachineInfo) Vector {
+	target := Vector{part2Offset + 1000 + rng.IntN(19000), part2Offset + 1000 + rng.IntN(19000)}
+	a, b, _ := presses(MachineInfo{A: info.A, B: info.B, Prize: target})
+	prize := Vector{a*info.A.X + b*info.B.X - part2Offset, a*info.A.Y + b*info.B.Y - part2Offset}
+	if a <= 0 || b <= 0 || prize.X <= 0 || prize.Y <= 0 {
+		return Vector{1000 + rng.IntN(19000), 1000 + rng.IntN(19000)}
+	}
+	return prize
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 320)
+}
@@ -1,14 +1,13 @@
-package main
+package day13
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
 	"math"
-	"strings"
-)
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
 type Vector struct {
 	X, Y int
@@ -90,24 +89,56 @@ func SolvePart2(infos []MachineInfo) int {
 	return total
 }
 
-func ReadInput(input string) []MachineInfo {
-	parts := strings.Split(input, "\n\n")
-	res := make([]MachineInfo, len(parts))
-	for i, part := range parts {
-		lines := strings.Split(part, "\n")
-		info := MachineInfo{}
-		fmt.Sscanf(lines[0], "Button A: X+%d, Y+%d", &info.A.X, &info.A.Y)
-		fmt.Sscanf(lines[1], "Button B: X+%d, Y+%d", &info.B.X, &info.B.Y)
-		fmt.Sscanf(lines[2], "Prize: X=%d, Y=%d", &info.Prize.X, &info.Prize.Y)
+func readMachine(block parse.Block) (MachineInfo, error) {
+	lines := parse.Lines(block.Text)
+	if len(lines) != 3 {
+		return MachineInfo{}, parse.Errorf(block.Line, 0, "want 3 lines for a machine, got %d", len(lines))
+	}
 
+	info := MachineInfo{}
+	if err := parse.Sscanf(lines[0], block.Line, "Button A: X+%d, Y+%d", &info.A.X, &info.A.Y); err != nil {
+		return MachineInfo{}, err
+	}
+	if err := parse.Sscanf(lines[1], block.Line+1, "Button B: X+%d, Y+%d", &info.B.X, &info.B.Y); err != nil {
+		return MachineInfo{}, err
+	}
+	if err := parse.Sscanf(lines[2], block.Line+2, "Prize: X=%d, Y=%d", &info.Prize.X, &info.Prize.Y); err != nil {
+		return MachineInfo{}, err
+	}
+	return info, nil
+}
+
+func ReadInput(input string) ([]MachineInfo, error) {
+	blocks := parse.Blocks(input)
+	res := make([]MachineInfo, len(blocks))
+	for i, block := range blocks {
+		info, err := readMachine(block)
+		if err != nil {
+			return nil, err
+		}
 		res[i] = info
 	}
 
-	return res
+	return res, nil
 }
 
-func main() {
-	infos := ReadInput(input)
-	fmt.Println("Part 1 Solution:", SolvePart1(infos))
-	fmt.Println("Part 2 Solution:", SolvePart2(infos))
+func init() {
+	registry.Register(registry.Day{
+		Number: 13,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			infos, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart1(infos), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			infos, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart2(infos), nil
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,8 +1,11 @@
-package main
+package day13
 
 import (
 	"slices"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
 )
 
 func CheckIfElementsAreSame[T comparable](t *testing.T, got, want []T) {
@@ -39,7 +42,10 @@ Button B: X+27, Y+71
 Prize: X=18641, Y=10279`
 
 func TestIsPrizePossible(t *testing.T) {
-	infos := ReadInput(testInput)
+	infos, err := ReadInput(testInput)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	testcases := []struct {
 		Name  string
 		Input MachineInfo
@@ -124,7 +130,10 @@ func TestIsPrizePossible(t *testing.T) {
 }
 
 func TestPart1Solution(t *testing.T) {
-	infos := ReadInput(testInput)
+	infos, err := ReadInput(testInput)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	got := SolvePart1(infos)
 	want := 480
 
@@ -133,18 +142,65 @@ func TestPart1Solution(t *testing.T) {
 	}
 }
 
-func BenchmarkPart1(b *testing.B) {
-	infos := ReadInput(input)
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		SolvePart1(infos)
+func TestGenerate(t *testing.T) {
+	input := GenerateInput(proptest.NewRand(1))
+	infos, err := ReadInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if len(infos) != 320 {
+		t.Errorf("Got wrong number of machines: got %d, want 320", len(infos))
+	}
+
+	won := 0
+	for _, info := range infos {
+		if isAlmostParallel(info.A, info.B) {
+			t.Errorf("Got parallel buttons: %v", info)
+		}
+		if isWonBackwards(info) || isWonBackwards(info.CalibirateForPart2()) {
+			t.Errorf("Got a prize won with negative presses: %v", info)
+		}
+		if _, possible := info.GetMinimumScore(func(i int) bool { return i > 100 }); possible {
+			won++
+		}
+	}
+	if won < 100 {
+		t.Errorf("Got too few prizes won in part 1: %d", won)
 	}
 }
 
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 13, 1)
+}
+
 func BenchmarkPart2(b *testing.B) {
-	infos := ReadInput(input)
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		SolvePart2(infos)
+	testutil.BenchPart(b, 13, 2)
+}
+
+func TestReadInputErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{
+			Name:    "prize line does not match",
+			Input:   "Button A: X+94, Y+34\nButton B: X+22, Y+67\nPrize: X=8400, Y=5400\n\nButton A: X+26, Y+66\nButton B: X+67, Y+21\nPrize: X=12748 Y=12176",
+			WantErr: `line 7: "Prize: X=12748 Y=12176" does not match "Prize: X=%d, Y=%d"`,
+		},
+		{
+			Name:    "machine without prize",
+			Input:   "Button A: X+94, Y+34\nButton B: X+22, Y+67\n\nButton A: X+26, Y+66\nButton B: X+67, Y+21\nPrize: X=12748, Y=12176",
+			WantErr: "line 1: want 3 lines for a machine, got 2",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := ReadInput(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
 	}
 }
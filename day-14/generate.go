@@ -0,0 +1,56 @@
+package day14
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// Generate returns the given number of robots in the space, moving by up to
+// 99 tiles a second along each axis. As in the puzzle, most of the robots
+// arrange themselves into a framed Christmas tree at a random time, before
+// the positions repeat. There must be more robots than tiles in the picture.
+func Generate(rng *rand.Rand, robots int, space Space) string {
+	picture := christmasTree(Vector{rng.IntN(space.Width - 31), rng.IntN(space.Height - 33)})
+	time := rng.IntN(space.Width * space.Height)
+
+	var builder strings.Builder
+	for i := range robots {
+		velocity := Vector{rng.IntN(199) - 99, rng.IntN(199) - 99}
+		pos := Vector{rng.IntN(space.Width), rng.IntN(space.Height)}
+		if i < len(picture) {
+			// Back from where the robot is in the picture.
+			pos = space.WrapPosition(picture[i].Add(velocity.Scale(-time)))
+		}
+		fmt.Fprintf(&builder, "p=%d,%d v=%d,%d\n", pos.X, pos.Y, velocity.X, velocity.Y)
+	}
+	return builder.String()
+}
+
+// Returns the tiles of a tree in a frame of 31x33 tiles, whose top left
+// corner is at the given position.
+func christmasTree(corner Vector) []Vector {
+	tiles := make([]Vector, 0)
+	for x := range 31 {
+		tiles = append(tiles, corner.Add(Vector{x, 0}), corner.Add(Vector{x, 32}))
+	}
+	for y := 1; y < 32; y++ {
+		tiles = append(tiles, corner.Add(Vector{0, y}), corner.Add(Vector{30, y}))
+	}
+	for row := range 15 {
+		for x := 15 - row; x <= 15+row; x++ {
+			tiles = append(tiles, corner.Add(Vector{x, 3 + row + row/2}))
+		}
+	}
+	for y := 26; y < 29; y++ {
+		for x := 14; x <= 16; x++ {
+			tiles = append(tiles, corner.Add(Vector{x, y}))
+		}
+	}
+	return tiles
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 500, Space{101, 103})
+}
@@ -1,19 +1,16 @@
-package main
+package day14
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
-	"image"
-	"image/color"
-	"log"
-	"strings"
 
-	"github.com/llgcode/draw2d/draw2dimg"
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
 )
 
-//go:embed input.txt
-var input string
-
 type Vector struct {
 	X, Y int
 }
@@ -96,45 +93,77 @@ func SolvePart1(robots []Robot, space Space) int {
 	return product
 }
 
-func GenerateSpaceImage(robots []Robot, space Space, time int) {
-	canvas := image.NewRGBA(image.Rect(0, 0, space.Width, space.Height))
-	for i := 0; i < space.Width; i++ {
-		for j := 0; j < space.Height; j++ {
-			canvas.Set(i, j, color.Black)
+// Tells the observer where the robots are at every time from 0 to the given
+// time, both included.
+func Simulate(ctx context.Context, robots []Robot, space Space, time int, observer trace.Observer[[]Vector]) error {
+	for t := 0; t <= time; t++ {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
+
+		positions := make([]Vector, len(robots))
+		for i, robot := range robots {
+			positions[i] = robot.PositionAfter(t, space)
+		}
+		observer.Observe(trace.Step[[]Vector]{Index: t, Action: "tick", State: positions})
 	}
-	for _, robot := range robots {
-		pos := robot.PositionAfter(time, space)
-		canvas.SetRGBA(pos.X, pos.Y, color.RGBA{0x00, 0xFF, 0x00, 0xFF})
-	}
-	err := draw2dimg.SaveToPngFile(fmt.Sprintf("day-14/generated/frame-%05d.png", time), canvas)
+	return nil
+}
+
+// Draws the robots, as '#', at every second until their positions repeat,
+// after width * height seconds.
+func Animate(ctx context.Context, input string, observer trace.Observer[grid.Grid[rune]]) error {
+	robots, err := ReadInput(input)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
+
+	space := Space{101, 103}
+	frame := grid.New(space.Width, space.Height, '.')
+	var previous []Vector
+	return Simulate(ctx, robots, space, space.Width*space.Height-1, trace.ObserverFunc[[]Vector](func(step trace.Step[[]Vector]) {
+		for _, pos := range previous {
+			frame.Set(grid.Position{X: pos.X, Y: pos.Y}, '.')
+		}
+		for _, pos := range step.State {
+			frame.Set(grid.Position{X: pos.X, Y: pos.Y}, '#')
+		}
+		previous = step.State
+		observer.Observe(trace.Step[grid.Grid[rune]]{Index: step.Index, Action: fmt.Sprintf("after %d seconds", step.Index), State: frame.Copy()})
+	}))
 }
 
-func ReadInput(input string) []Robot {
-	lines := strings.Split(input, "\n")
+func ReadInput(input string) ([]Robot, error) {
+	lines := parse.Lines(input)
 	res := make([]Robot, len(lines))
 	for i, line := range lines {
-		fmt.Sscanf(
+		err := parse.Sscanf(
 			line,
+			i+1,
 			"p=%d,%d v=%d,%d",
 			&res[i].InitialPos.X,
 			&res[i].InitialPos.Y,
 			&res[i].Velocity.X,
 			&res[i].Velocity.Y,
 		)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return res
+	return res, nil
 }
 
-func main() {
-	robots := ReadInput(input)
-	fmt.Println("Part 1 solution:", SolvePart1(robots, Space{101, 103}))
-	space := Space{101, 103}
-
-	for i := 0; i < 10000; i++ {
-		GenerateSpaceImage(robots, space, i)
-	}
+func init() {
+	registry.Register(registry.Day{
+		Number: 14,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			robots, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart1(robots, Space{101, 103}), nil
+		},
+		Generate: GenerateInput,
+	})
+	animate.Register(14, Animate)
 }
@@ -1,7 +1,11 @@
-package main
+package day14
 
 import (
+	"context"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
 )
 
 var testInput = `p=0,4 v=3,-3
@@ -61,6 +65,26 @@ func TestPositionAfterTime(t *testing.T) {
 	}
 }
 
+func TestSimulate(t *testing.T) {
+	robots := []Robot{{Vector{2, 4}, Vector{2, -3}}, {Vector{0, 0}, Vector{1, 1}}}
+	recorder := &trace.Recorder[[]Vector]{}
+
+	if err := Simulate(context.Background(), robots, Space{11, 7}, 5, recorder); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if len(recorder.Steps) != 6 {
+		t.Fatalf("Got wrong number of steps: got %d, want 6", len(recorder.Steps))
+	}
+	for _, step := range recorder.Steps {
+		for i, robot := range robots {
+			if want := robot.PositionAfter(step.Index, Space{11, 7}); step.State[i] != want {
+				t.Errorf("Got wrong position of robot %d at time %d: got %v, want %v", i, step.Index, step.State[i], want)
+			}
+		}
+	}
+}
+
 func TestFindQuadrantOfPos(t *testing.T) {
 	testcases := []struct {
 		Name     string
@@ -117,7 +141,10 @@ func TestFindQuadrantOfPos(t *testing.T) {
 }
 
 func TestPart1Solution(t *testing.T) {
-	robots := ReadInput(testInput)
+	robots, err := ReadInput(testInput)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	space := Space{11, 7}
 	want := 12
 	got := SolvePart1(robots, space)
@@ -126,3 +153,14 @@ func TestPart1Solution(t *testing.T) {
 		t.Errorf("Got wrong output: got %d, want %d", got, want)
 	}
 }
+
+func TestReadInputErrors(t *testing.T) {
+	_, err := ReadInput("p=0,4 v=3,-3\np=6,3 v=-1")
+	if want := `line 2: "p=6,3 v=-1" does not match "p=%d,%d v=%d,%d"`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 14, 1)
+}
@@ -0,0 +1,44 @@
+package day15
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+)
+
+// Generate returns a warehouse of the given size, walls included, with walls
+// and boxes on about the given fractions of the cells inside, followed by the
+// given number of random moves, at least one, 70 to a line.
+func Generate(rng *rand.Rand, width, height int, walls, boxes float64, moves int) string {
+	warehouse := grid.New(width, height, '#')
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			cell := '.'
+			switch r := rng.Float64(); {
+			case r < walls:
+				cell = '#'
+			case r < walls+boxes:
+				cell = 'O'
+			}
+			warehouse.Set(Position{X: x, Y: y}, cell)
+		}
+	}
+	warehouse.Set(Position{X: 1 + rng.IntN(width-2), Y: 1 + rng.IntN(height-2)}, '@')
+
+	var builder strings.Builder
+	builder.WriteString(warehouse.String())
+	builder.WriteByte('\n')
+	for i := range moves {
+		builder.WriteByte("<>^v"[rng.IntN(4)])
+		if (i+1)%70 == 0 || i == moves-1 {
+			builder.WriteByte('\n')
+		}
+	}
+	return builder.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 50, 50, 0.05, 0.25, 20000)
+}
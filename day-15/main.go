@@ -1,130 +1,157 @@
-package main
+package day15
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
+	"slices"
 	"strings"
-)
-
-//go:embed input.txt
-var input string
 
-type Position struct {
-	X, Y int
-}
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
 
-type Direction struct {
-	Dx, Dy int
-}
+type (
+	Direction = grid.Direction
+	Position  = grid.Position
+)
 
 var (
-	RIGHT = Direction{1, 0}
-	LEFT  = Direction{-1, 0}
-	UP    = Direction{0, -1}
-	DOWN  = Direction{0, 1}
+	RIGHT = grid.RIGHT
+	LEFT  = grid.LEFT
+	UP    = grid.UP
+	DOWN  = grid.DOWN
 )
 
 type Grid struct {
-	Width  int
-	Height int
-	Cells  [][]rune
-	Robot  Position
+	grid.Grid[rune]
+	Robot Position
+
+	// Told about every move of the robot, if set. It is not kept by Copy.
+	Observer trace.Observer[Snapshot]
+	steps    int
 }
 
 func (grid Grid) Copy() Grid {
-	cells := make([][]rune, len(grid.Cells))
-	for i, row := range grid.Cells {
-		newRow := make([]rune, len(row))
-		copy(newRow, row)
-		cells[i] = newRow
-	}
-	return Grid{
-		Width:  grid.Width,
-		Height: grid.Height,
-		Robot:  grid.Robot,
-		Cells:  cells,
-	}
-}
-
-func ReadGridText(gridText string) Grid {
-	lines := strings.Split(gridText, "\n")
-	grid := Grid{
-		Width:  len(lines[0]),
-		Height: len(lines),
-		Cells:  make([][]rune, len(lines)),
-		Robot:  Position{},
-	}
-
-	for i, line := range lines {
-		row := make([]rune, len(line))
-		for j, char := range line {
-			row[j] = char
-			if char == '@' {
-				grid.Robot = Position{j, i}
-			}
-		}
-		grid.Cells[i] = row
-	}
-	return grid
-}
-
-func ReadInputPart1(input string) (Grid, string) {
-	gridText, moves, _ := strings.Cut(input, "\n\n")
-	return ReadGridText(gridText), moves
-}
-
-func ReadGridTextPart2(gridText string) Grid {
-	lines := strings.Split(gridText, "\n")
-	grid := Grid{
-		Width:  len(lines[0]) * 2,
-		Height: len(lines),
-		Cells:  make([][]rune, len(lines)),
-		Robot:  Position{},
-	}
-
-	for i, line := range lines {
-		row := make([]rune, 0, grid.Width)
-		for j, char := range line {
-			switch char {
-			case 'O':
-				row = append(row, []rune("[]")...)
-			case '#':
-				row = append(row, []rune("##")...)
-			case '@':
-				row = append(row, []rune("@.")...)
-				grid.Robot = Position{2 * j, i}
-			case '.':
-				row = append(row, []rune("..")...)
-			}
-		}
-		grid.Cells[i] = row
+	return Grid{Grid: grid.Grid.Copy(), Robot: grid.Robot}
+}
+
+// The warehouse after a move, as given to the observer, with a string for
+// each row.
+type Snapshot struct {
+	Robot Position
+	Rows  []string
+}
+
+func (grid Grid) Snapshot() Snapshot {
+	rows := strings.Split(strings.TrimSuffix(grid.String(), "\n"), "\n")
+	return Snapshot{grid.Robot, rows}
+}
+
+// Returns the warehouse of the snapshot as a grid.
+func (snapshot Snapshot) Grid() grid.Grid[rune] {
+	cells := make([][]rune, len(snapshot.Rows))
+	for y, row := range snapshot.Rows {
+		cells[y] = []rune(row)
 	}
-	return grid
+	return grid.FromCells(cells)
 }
 
-func ReadInputPart2(input string) (Grid, string) {
-	gridText, moves, _ := strings.Cut(input, "\n\n")
-	return ReadGridTextPart2(gridText), moves
+var directionNames = map[Direction]string{RIGHT: "right", LEFT: "left", UP: "up", DOWN: "down"}
+
+func (grid *Grid) observe(action string) {
+	if grid.Observer == nil {
+		return
+	}
+	grid.Observer.Observe(trace.Step[Snapshot]{Index: grid.steps, Action: action, State: grid.Snapshot()})
+	grid.steps++
+}
+
+// Find the robot in the grid, marked by '@'. There should be exactly one.
+func findRobot(cells grid.Grid[rune]) (Position, error) {
+	robots := cells.FindAll(func(char rune) bool { return char == '@' })
+	if len(robots) != 1 {
+		return Position{}, fmt.Errorf("want 1 robot in the warehouse, got %d", len(robots))
+	}
+	return robots[0], nil
+}
+
+// Read the grid as it is. It can also be a grid that is already doubled in width.
+func ReadGridText(gridText string) (Grid, error) {
+	cells, err := grid.Parse(gridText, grid.RunesIn(".#O@[]"))
+	if err != nil {
+		return Grid{}, err
+	}
+
+	robot, err := findRobot(cells)
+	return Grid{Grid: cells, Robot: robot}, err
 }
 
-func (grid Grid) Set(pos Position, char rune) {
-	grid.Cells[pos.Y][pos.X] = char
+// Read the grid with every cell doubled in width.
+func ReadGridTextPart2(gridText string) (Grid, error) {
+	cells, err := grid.Parse(gridText, func(char rune) ([]rune, error) {
+		switch char {
+		case 'O':
+			return []rune("[]"), nil
+		case '@':
+			return []rune("@."), nil
+		case '#', '.':
+			return []rune{char, char}, nil
+		}
+		return nil, fmt.Errorf("unexpected %q, want one of %q", char, ".#O@")
+	})
+	if err != nil {
+		return Grid{}, err
+	}
+
+	wideCells := make([][]rune, cells.Height)
+	for y, row := range cells.Cells {
+		wideCells[y] = slices.Concat(row...)
+	}
+
+	wideGrid := grid.FromCells(wideCells)
+	robot, err := findRobot(wideGrid)
+	return Grid{Grid: wideGrid, Robot: robot}, err
 }
 
-func (grid Grid) At(pos Position) rune {
-	return grid.Cells[pos.Y][pos.X]
+// Check that the moves only have arrows, possibly split on multiple lines.
+func readMoves(block parse.Block) (string, error) {
+	for i, line := range parse.Lines(block.Text) {
+		for j, move := range line {
+			if !strings.ContainsRune("<>^v", move) {
+				return "", parse.Errorf(block.Line+i, j+1, "unexpected %q, want one of %q", move, "<>^v")
+			}
+		}
+	}
+	return block.Text, nil
 }
 
-func (grid Grid) State() string {
-	state := ""
-	for _, row := range grid.Cells {
-		state += string(row) + "\n"
+func readInput(input string, readGridText func(string) (Grid, error)) (Grid, string, error) {
+	blocks := parse.Blocks(input)
+	if len(blocks) != 2 {
+		return Grid{}, "", fmt.Errorf("want the warehouse and the moves separated by a blank line, got %d sections", len(blocks))
+	}
+
+	grid, err := readGridText(blocks[0].Text)
+	if err != nil {
+		return Grid{}, "", parse.Offset(err, blocks[0].Line, 1)
 	}
-	return state
+
+	moves, err := readMoves(blocks[1])
+	if err != nil {
+		return Grid{}, "", err
+	}
+	return grid, moves, nil
+}
+
+func ReadInputPart1(input string) (Grid, string, error) {
+	return readInput(input, ReadGridText)
 }
 
-func (pos Position) MoveAlong(dir Direction) Position {
-	return Position{pos.X + dir.Dx, pos.Y + dir.Dy}
+func ReadInputPart2(input string) (Grid, string, error) {
+	return readInput(input, ReadGridTextPart2)
 }
 
 func ApplyMoves(grid *Grid, moves string) {
@@ -200,46 +227,80 @@ func MoveCellsAlongDirection(grid *Grid, pos Position, dir Direction) {
 
 // Move the robot in specified direction if possible. Otherwise do not do anything
 func MoveRobot(grid *Grid, dir Direction) {
-	if CanTheCellBeMoved(*grid, grid.Robot, dir) {
-		MoveCellsAlongDirection(grid, grid.Robot, dir)
-
-		nextPos := grid.Robot.MoveAlong(dir)
-		grid.Robot = nextPos
+	if !CanTheCellBeMoved(*grid, grid.Robot, dir) {
+		grid.observe("blocked " + directionNames[dir])
+		return
 	}
+
+	MoveCellsAlongDirection(grid, grid.Robot, dir)
+	nextPos := grid.Robot.MoveAlong(dir)
+	grid.Robot = nextPos
+	grid.observe("move " + directionNames[dir])
 }
 
-func (pos Position) GPS() int {
+func GPS(pos Position) int {
 	return 100*pos.Y + pos.X
 }
 
 func (grid Grid) FindTotalScore() int {
 	total := 0
-	for x := 0; x < grid.Width; x++ {
-		for y := 0; y < grid.Height; y++ {
-			pos := Position{x, y}
-			if grid.At(pos) == 'O' || grid.At(pos) == '[' {
-				total += pos.GPS()
-			}
+	for pos, char := range grid.All() {
+		if char == 'O' || char == '[' {
+			total += GPS(pos)
 		}
 	}
 	return total
 }
 
-func SolveForPart1(input string) int {
-	grid, moves := ReadInputPart1(input)
+func SolveForPart1(input string) (int, error) {
+	grid, moves, err := ReadInputPart1(input)
+	if err != nil {
+		return 0, err
+	}
 
 	ApplyMoves(&grid, moves)
-	return grid.FindTotalScore()
+	return grid.FindTotalScore(), nil
 }
 
-func SolveForPart2(input string) int {
-	grid, moves := ReadInputPart2(input)
+func SolveForPart2(input string) (int, error) {
+	grid, moves, err := ReadInputPart2(input)
+	if err != nil {
+		return 0, err
+	}
 
 	ApplyMoves(&grid, moves)
-	return grid.FindTotalScore()
+	return grid.FindTotalScore(), nil
+}
+
+// Draws the wide warehouse of part 2 after every move of the robot.
+func Animate(ctx context.Context, input string, observer trace.Observer[grid.Grid[rune]]) error {
+	warehouse, moves, err := ReadInputPart2(input)
+	if err != nil {
+		return err
+	}
+
+	warehouse.Observer = trace.ObserverFunc[Snapshot](func(step trace.Step[Snapshot]) {
+		observer.Observe(trace.Step[grid.Grid[rune]]{Index: step.Index, Action: step.Action, State: step.State.Grid()})
+	})
+	for _, move := range moves {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ApplyMoves(&warehouse, string(move))
+	}
+	return nil
 }
 
-func main() {
-	fmt.Println("Part 1 solution:", SolveForPart1(input))
-	fmt.Println("Part 2 solution:", SolveForPart2(input))
+func init() {
+	registry.Register(registry.Day{
+		Number: 15,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			return SolveForPart1(input)
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			return SolveForPart2(input)
+		},
+		Generate: GenerateInput,
+	})
+	animate.Register(15, Animate)
 }
@@ -1,46 +1,43 @@
-package main
+package day15
 
 import (
+	"context"
+	"math/rand/v2"
 	"reflect"
 	"slices"
+	"strings"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
 )
 
-var smallTestInput = `########
-#..O.O.#
-##@.O..#
-#...O..#
-#.#.O..#
-#...O..#
-#......#
-########
-
-<^^>>>vv<v>>v<<`
-
-var testInput = `##########
-#..O..O.O#
-#......O.#
-#.OO..O.O#
-#..O@..O.#
-#O#..O...#
-#O..O..O.#
-#.OO.O.OO#
-#....O...#
-##########
-
-<vv>^<v^>v>^vv^v>v<>v^v<v<^vv<<<^><<><>>v<vvv<>^v^>^<<<><<v<<<v^vv^v>^
-vvv<<^>^v^^><<>>><>^<<><^vv^^<>vvv<>><^^v>^>vv<>v<<<<v<^v>^<^^>>>^<v<v
-><>vv>v^v^<>><>>>><^^>vv>v<^^^>>v^v^<^^>v^^>v^<^v>v<>>v^v^<v>v^^<^^vv<
-<<v<^>>^^^^>>>v^<>vvv^><v<<<>^^^vv^<vvv>^>v<^^^^v<>^>vvvv><>>v^<<^^^^^
-^><^><>>><>^^<<^^v>>><^<v>^<vv>>v>>>^v><>^v><<<<v>>v<v<v>vvv>^<><<>^><
-^>><>^v<><^vvv<^^<><v<<<<<><^v<<<><<<^^<v<^^^><^>>^<v^><<<^>>^v<v^v<v^
->^>>^v>vv>^<<^v<>><<><<v<<v><>v<^vv<<<>^^v^>^^>>><<^v>>v^v><^^>>^<>vv^
-<><^^>^^^<><vvvvv^v<v<<>^v<v>v<<^><<><<><<<^^<<<^<<>><<><^^^>^^<>^>v<>
-^^>vv<^v^v<vv>^<><v<^v>^^^>>>^^vvv^>vvv<>>>^<^>>>>>^<<^v>^vvv<>^<><<v>
-v^^>>><<^^<>>^v^<v^vv<>v^<<>^<^v^v><^<<<><<^<v><v<>vv>>v><v^<vv<>v^<<^`
+var smallTestInput = testutil.MustReadExample("example-1.txt")
+
+var testInput = testutil.MustReadExample("example-2.txt")
+
+func mustReadInput(t *testing.T, readInput func(string) (Grid, string, error), input string) (Grid, string) {
+	t.Helper()
+	grid, moves, err := readInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	return grid, moves
+}
+
+func mustReadGridText(t *testing.T, gridText string) Grid {
+	t.Helper()
+	grid, err := ReadGridText(gridText)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	return grid
+}
 
 func TestRobotMovesInDirectionSpecified(t *testing.T) {
-	grid, _ := ReadInputPart1(testInput)
+	grid, _ := mustReadInput(t, ReadInputPart1, testInput)
 	testcases := []struct {
 		Name         string
 		Grid         Grid
@@ -51,72 +48,72 @@ func TestRobotMovesInDirectionSpecified(t *testing.T) {
 		{
 			Name:         "move robot in the specified direction",
 			Grid:         grid,
-			RobotPos:     Position{4, 2},
+			RobotPos:     Position{X: 4, Y: 2},
 			Dir:          UP,
-			WantRobotPos: Position{4, 1},
+			WantRobotPos: Position{X: 4, Y: 1},
 		},
 		{
 			Name:         "move robot in the specified direction",
 			Grid:         grid,
-			RobotPos:     Position{4, 2},
+			RobotPos:     Position{X: 4, Y: 2},
 			Dir:          DOWN,
-			WantRobotPos: Position{4, 3},
+			WantRobotPos: Position{X: 4, Y: 3},
 		},
 		{
 			Name:         "move robot in the specified direction",
 			Grid:         grid,
-			RobotPos:     Position{4, 2},
+			RobotPos:     Position{X: 4, Y: 2},
 			Dir:          RIGHT,
-			WantRobotPos: Position{5, 2},
+			WantRobotPos: Position{X: 5, Y: 2},
 		},
 		{
 			Name:         "move robot in the specified direction",
 			Grid:         grid,
-			RobotPos:     Position{4, 2},
+			RobotPos:     Position{X: 4, Y: 2},
 			Dir:          LEFT,
-			WantRobotPos: Position{3, 2},
+			WantRobotPos: Position{X: 3, Y: 2},
 		},
 		{
 			Name:         "do not move robot if there is wall in new position",
 			Grid:         grid,
-			RobotPos:     Position{1, 1},
+			RobotPos:     Position{X: 1, Y: 1},
 			Dir:          UP,
-			WantRobotPos: Position{1, 1},
+			WantRobotPos: Position{X: 1, Y: 1},
 		},
 		{
 			Name:         "do not move robot if there is wall in new position",
 			Grid:         grid,
-			RobotPos:     Position{1, 1},
+			RobotPos:     Position{X: 1, Y: 1},
 			Dir:          LEFT,
-			WantRobotPos: Position{1, 1},
+			WantRobotPos: Position{X: 1, Y: 1},
 		},
 		{
 			Name:         "do not move robot if there is box and then wall",
 			Grid:         grid,
-			RobotPos:     Position{7, 1},
+			RobotPos:     Position{X: 7, Y: 1},
 			Dir:          RIGHT,
-			WantRobotPos: Position{7, 1},
+			WantRobotPos: Position{X: 7, Y: 1},
 		},
 		{
 			Name:         "do not move robot if there is box and then wall",
 			Grid:         grid,
-			RobotPos:     Position{3, 2},
+			RobotPos:     Position{X: 3, Y: 2},
 			Dir:          UP,
-			WantRobotPos: Position{3, 2},
+			WantRobotPos: Position{X: 3, Y: 2},
 		},
 		{
 			Name:         "do not move robot if there are multiple boxes and then wall",
 			Grid:         grid,
-			RobotPos:     Position{5, 6},
+			RobotPos:     Position{X: 5, Y: 6},
 			Dir:          DOWN,
-			WantRobotPos: Position{5, 6},
+			WantRobotPos: Position{X: 5, Y: 6},
 		},
 		{
 			Name:         "do not move robot if there are multiple boxes and then wall",
 			Grid:         grid,
-			RobotPos:     Position{6, 7},
+			RobotPos:     Position{X: 6, Y: 7},
 			Dir:          RIGHT,
-			WantRobotPos: Position{6, 7},
+			WantRobotPos: Position{X: 6, Y: 7},
 		},
 	}
 
@@ -163,7 +160,7 @@ func CheckIfElementsAreSame[T comparable](t *testing.T, got, want []T) {
 }
 
 func TestRobotMovesTheBoxesInMiddle(t *testing.T) {
-	grid, _ := ReadInputPart1(testInput)
+	grid, _ := mustReadInput(t, ReadInputPart1, testInput)
 	testcases := []struct {
 		Name         string
 		Grid         Grid
@@ -176,20 +173,20 @@ func TestRobotMovesTheBoxesInMiddle(t *testing.T) {
 		{
 			Name:         "move the single box in the direction",
 			Grid:         grid,
-			RobotPos:     Position{4, 4},
+			RobotPos:     Position{X: 4, Y: 4},
 			Dir:          LEFT,
-			WantRobotPos: Position{3, 4},
-			OldBoxPos:    []Position{{3, 4}},
-			NewBoxPos:    []Position{{2, 4}},
+			WantRobotPos: Position{X: 3, Y: 4},
+			OldBoxPos:    []Position{{X: 3, Y: 4}},
+			NewBoxPos:    []Position{{X: 2, Y: 4}},
 		},
 		{
 			Name:         "move the multiple boxes in the direction",
 			Grid:         grid,
-			RobotPos:     Position{4, 3},
+			RobotPos:     Position{X: 4, Y: 3},
 			Dir:          LEFT,
-			WantRobotPos: Position{3, 3},
-			OldBoxPos:    []Position{{3, 3}, {2, 3}},
-			NewBoxPos:    []Position{{2, 3}, {1, 3}},
+			WantRobotPos: Position{X: 3, Y: 3},
+			OldBoxPos:    []Position{{X: 3, Y: 3}, {X: 2, Y: 3}},
+			NewBoxPos:    []Position{{X: 2, Y: 3}, {X: 1, Y: 3}},
 		},
 	}
 
@@ -233,16 +230,39 @@ func TestGridAfterApplyingMoves(t *testing.T) {
 #O.....OO#
 #OO....OO#
 ##########`
-	grid, moves := ReadInputPart1(testInput)
+	grid, moves := mustReadInput(t, ReadInputPart1, testInput)
 
 	ApplyMoves(&grid, moves)
 
-	finalGrid := ReadGridText(afterGrid)
+	finalGrid := mustReadGridText(t, afterGrid)
 	if !reflect.DeepEqual(grid, finalGrid) {
 		t.Errorf("Expected grid is not achieved")
 	}
 }
 
+func TestObserveRobotMoves(t *testing.T) {
+	grid := mustReadGridText(t, "#####\n#.O@#\n#####")
+	recorder := &trace.Recorder[Snapshot]{}
+	grid.Observer = recorder
+
+	ApplyMoves(&grid, "<<>")
+
+	wantActions := []string{"move left", "blocked left", "move right"}
+	if got := recorder.Actions(); !reflect.DeepEqual(got, wantActions) {
+		t.Errorf("Got wrong actions: got %v, want %v", got, wantActions)
+	}
+	wantStates := []Snapshot{
+		{Position{X: 2, Y: 1}, []string{"#####", "#O@.#", "#####"}},
+		{Position{X: 2, Y: 1}, []string{"#####", "#O@.#", "#####"}},
+		{Position{X: 3, Y: 1}, []string{"#####", "#O.@#", "#####"}},
+	}
+	for i, step := range recorder.Steps {
+		if step.Index != i || !reflect.DeepEqual(step.State, wantStates[i]) {
+			t.Errorf("Got wrong step %d: got %+v, want %+v", i, step, wantStates[i])
+		}
+	}
+}
+
 func TestTotalScoreOfTheGrid(t *testing.T) {
 	afterGrid := `##########
 #.O.O.OOO#
@@ -255,7 +275,7 @@ func TestTotalScoreOfTheGrid(t *testing.T) {
 #OO....OO#
 ##########`
 
-	finalGrid := ReadGridText(afterGrid)
+	finalGrid := mustReadGridText(t, afterGrid)
 	got := finalGrid.FindTotalScore()
 	want := 10092
 	if got != want {
@@ -264,7 +284,10 @@ func TestTotalScoreOfTheGrid(t *testing.T) {
 }
 
 func TestPart1Solution(t *testing.T) {
-	got := SolveForPart1(testInput)
+	got, err := SolveForPart1(testInput)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	want := 10092
 	if got != want {
 		t.Errorf("Got wrong score: got %d, want %d", got, want)
@@ -272,7 +295,7 @@ func TestPart1Solution(t *testing.T) {
 }
 
 func TestRobotMovesInDirectionSpecifiedPart2(t *testing.T) {
-	grid, _ := ReadInputPart2(testInput)
+	grid, _ := mustReadInput(t, ReadInputPart2, testInput)
 	testcases := []struct {
 		Name         string
 		Grid         Grid
@@ -283,50 +306,50 @@ func TestRobotMovesInDirectionSpecifiedPart2(t *testing.T) {
 		{
 			Name:         "move robot in specified direction if it is empty",
 			Grid:         grid,
-			RobotPos:     Position{3, 2},
+			RobotPos:     Position{X: 3, Y: 2},
 			Dir:          UP,
-			WantRobotPos: Position{3, 1},
+			WantRobotPos: Position{X: 3, Y: 1},
 		},
 		{
 			Name:         "move robot in specified direction if it is empty",
 			Grid:         grid,
-			RobotPos:     Position{3, 2},
+			RobotPos:     Position{X: 3, Y: 2},
 			Dir:          RIGHT,
-			WantRobotPos: Position{4, 2},
+			WantRobotPos: Position{X: 4, Y: 2},
 		},
 		{
 			Name:         "do not move the robot if next is a wall",
 			Grid:         grid,
-			RobotPos:     Position{3, 1},
+			RobotPos:     Position{X: 3, Y: 1},
 			Dir:          UP,
-			WantRobotPos: Position{3, 1},
+			WantRobotPos: Position{X: 3, Y: 1},
 		},
 		{
 			Name:         "move the robot if next is a box and can move box in direction",
 			Grid:         grid,
-			RobotPos:     Position{8, 4},
+			RobotPos:     Position{X: 8, Y: 4},
 			Dir:          LEFT,
-			WantRobotPos: Position{7, 4},
+			WantRobotPos: Position{X: 7, Y: 4},
 		},
 		{
 			Name:         "do not move if next is box, followed by wall",
 			Grid:         grid,
-			RobotPos:     Position{4, 6},
+			RobotPos:     Position{X: 4, Y: 6},
 			Dir:          LEFT,
-			WantRobotPos: Position{4, 6},
+			WantRobotPos: Position{X: 4, Y: 6},
 		},
 		{
 			Name: "do not move if one of the boxes touches a wall",
-			Grid: ReadGridText(`##############
+			Grid: mustReadGridText(t, `##############
 ##......##..##
 ##...[][]...##
 ##....[]....##
 ##.....@....##
 ##..........##
 ##############`),
-			RobotPos:     Position{7, 4},
+			RobotPos:     Position{X: 7, Y: 4},
 			Dir:          UP,
-			WantRobotPos: Position{7, 4},
+			WantRobotPos: Position{X: 7, Y: 4},
 		},
 	}
 
@@ -439,13 +462,13 @@ func TestWhetherBoxesMoveCorrectlyForPart2(t *testing.T) {
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
 
-			grid := ReadGridText(testcase.Grid)
-			newGrid := ReadGridText(testcase.NewGrid)
+			grid := mustReadGridText(t, testcase.Grid)
+			newGrid := mustReadGridText(t, testcase.NewGrid)
 
 			MoveCellsAlongDirection(&grid, grid.Robot, testcase.Dir)
 
-			if grid.State() != newGrid.State() {
-				t.Errorf("Final grid is not as expected: \nGot: \n%s\n\nWant: \n%s", grid.State(), newGrid.State())
+			if grid.String() != newGrid.String() {
+				t.Errorf("Final grid is not as expected: \nGot: \n%s\n\nWant: \n%s", grid.String(), newGrid.String())
 			}
 		})
 	}
@@ -463,19 +486,155 @@ func TestGridAfterApplyingMovesPart2(t *testing.T) {
 ##......[][]..[]..##
 ####################
 `
-	grid, moves := ReadInputPart2(testInput)
+	grid, moves := mustReadInput(t, ReadInputPart2, testInput)
 
 	ApplyMoves(&grid, moves)
 
-	if grid.State() != afterGrid {
-		t.Errorf("Final grid is not as expected: \nGot: \n%s\n\nWant: \n%s", grid.State(), afterGrid)
+	if grid.String() != afterGrid {
+		t.Errorf("Final grid is not as expected: \nGot: \n%s\n\nWant: \n%s", grid.String(), afterGrid)
 	}
 }
 
 func TestPart2Solution(t *testing.T) {
-	got := SolveForPart2(testInput)
+	got, err := SolveForPart2(testInput)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	want := 9021
 	if got != want {
 		t.Errorf("Got wrong score: got %d, want %d", got, want)
 	}
 }
+
+func TestReadInputErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"unknown cell in warehouse", "#####\n#.@x#\n#####\n\n<<", `line 2, column 4: unexpected 'x', want one of ".#O@"`},
+		{"no robot", "#####\n#..O#\n#####\n\n<<", "want 1 robot in the warehouse, got 0"},
+		{"unknown move", "#####\n#.@O#\n#####\n\n<<>\n^x", `line 6, column 2: unexpected 'x', want one of "<>^v"`},
+		{"no moves", "#####\n#.@O#\n#####\n", "want the warehouse and the moves separated by a blank line, got 1 sections"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, _, err := ReadInputPart2(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestAnimate(t *testing.T) {
+	recorder := &trace.Recorder[grid.Grid[rune]]{}
+	if err := Animate(context.Background(), "#####\n#.O@#\n#####\n\n<", recorder); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if len(recorder.Steps) != 1 {
+		t.Fatalf("Got wrong number of frames: got %d, want 1", len(recorder.Steps))
+	}
+	if got, want := recorder.Steps[0].State.String(), "##########\n##.[]@..##\n##########\n"; got != want {
+		t.Errorf("Got wrong frame:\n%swant:\n%s", got, want)
+	}
+}
+
+// Moves the robot by finding every cell pushed by the move before moving
+// them, in a warehouse where the boxes are either 'O' or "[]".
+func bruteForceGPS(rows []string, moves string) int {
+	cells := make([][]rune, len(rows))
+	var robot Position
+	for y, row := range rows {
+		cells[y] = []rune(row)
+		if x := strings.IndexRune(row, '@'); x >= 0 {
+			robot = Position{X: x, Y: y}
+		}
+	}
+	at := func(pos Position) rune { return cells[pos.Y][pos.X] }
+
+	dirs := map[rune]Direction{'<': LEFT, '>': RIGHT, '^': UP, 'v': DOWN}
+	for _, move := range moves {
+		dir, found := dirs[move]
+		if !found {
+			continue
+		}
+
+		pushed := []Position{robot}
+		blocked := false
+		for i := 0; i < len(pushed) && !blocked; i++ {
+			next := pushed[i].MoveAlong(dir)
+			if slices.Contains(pushed, next) {
+				continue
+			}
+			switch at(next) {
+			case '#':
+				blocked = true
+			case 'O':
+				pushed = append(pushed, next)
+			case '[':
+				pushed = append(pushed, next, next.MoveAlong(RIGHT))
+			case ']':
+				pushed = append(pushed, next, next.MoveAlong(LEFT))
+			}
+		}
+		if blocked {
+			continue
+		}
+
+		moved := make([]rune, len(pushed))
+		for i, pos := range pushed {
+			moved[i] = at(pos)
+			cells[pos.Y][pos.X] = '.'
+		}
+		for i, pos := range pushed {
+			next := pos.MoveAlong(dir)
+			cells[next.Y][next.X] = moved[i]
+		}
+		robot = robot.MoveAlong(dir)
+	}
+
+	total := 0
+	for y, row := range cells {
+		for x, cell := range row {
+			if cell == 'O' || cell == '[' {
+				total += 100*y + x
+			}
+		}
+	}
+	return total
+}
+
+func widen(rows []string) []string {
+	replacer := strings.NewReplacer("#", "##", "O", "[]", ".", "..", "@", "@.")
+	wide := make([]string, len(rows))
+	for i, row := range rows {
+		wide[i] = replacer.Replace(row)
+	}
+	return wide
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string {
+		return Generate(rng, 3+rng.IntN(10), 3+rng.IntN(10), 0.1, 0.3, 1+rng.IntN(200))
+	}
+	proptest.CheckParts(t, 15, generate, func(input string) (any, any) {
+		warehouse, moves, _ := strings.Cut(input, "\n\n")
+		rows := strings.Split(warehouse, "\n")
+		return bruteForceGPS(rows, moves), bruteForceGPS(widen(rows), moves)
+	})
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 15, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 15, 2)
+}
+
+func TestExamples(t *testing.T) {
+	testutil.Examples(t, 15)
+}
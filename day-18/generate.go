@@ -0,0 +1,41 @@
+package day18
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+)
+
+// Generate returns the given number of bytes falling on distinct positions of
+// a memory space of the given size, never on the corners. As in the puzzle,
+// the exit can still be reached once the first [fallen] bytes have fallen,
+// but not once all of them have. Bytes falling where they block the exit too
+// early, or not at all, are thrown away.
+func Generate(rng *rand.Rand, size Size, bytes, fallen int) string {
+	start := Position{X: 0, Y: 0}
+	end := Position{X: size.Width - 1, Y: size.Height - 1}
+	for {
+		obstacles := make([]Position, 0, bytes)
+		for _, cell := range rng.Perm(size.Width * size.Height) {
+			pos := Position{X: cell % size.Width, Y: cell / size.Width}
+			if pos != start && pos != end && len(obstacles) < bytes {
+				obstacles = append(obstacles, pos)
+			}
+		}
+
+		open := FindShortestPathWithObstacles(start, end, Grid{size, obstacles[:fallen]}) != math.MaxInt
+		if open && FindPathWithObstacles(start, end, Grid{size, obstacles}) == nil {
+			var builder strings.Builder
+			for _, pos := range obstacles {
+				fmt.Fprintf(&builder, "%d,%d\n", pos.X, pos.Y)
+			}
+			return builder.String()
+		}
+	}
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, Size{Width: 71, Height: 71}, 3450, 1024)
+}
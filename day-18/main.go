@@ -1,121 +1,143 @@
-package main
+package day18
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
 	"math"
 	"slices"
-	"strings"
-)
-
-//go:embed input.txt
-var input string
-
-type Direction struct {
-	Dx, Dy int
-}
-
-var ALL_DIRS = []Direction{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
 
-type Position struct {
-	X, Y int
-}
-
-func (pos Position) MoveAlong(dir Direction) Position {
-	return Position{pos.X + dir.Dx, pos.Y + dir.Dy}
-}
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/search"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
 
-func Abs(x int) int {
-	if x < 0 {
-		return -x
-	}
-	return x
-}
+type (
+	Position = grid.Position
+	Size     = grid.Size
+)
 
 func FindShortestPath(start, end Position) int {
-	xDiff := end.X - start.X
-	yDiff := end.Y - start.Y
-	return Abs(xDiff) + Abs(yDiff)
+	return grid.ManhattanDistance(start, end)
 }
 
 type Grid struct {
-	Width     int
-	Height    int
+	Size
 	Obstacles []Position
 }
 
-func (grid Grid) IsInBounds(pos Position) bool {
-	return 0 <= pos.X && pos.X < grid.Width && 0 <= pos.Y && pos.Y < grid.Height
+func FindShortestPathWithObstacles(start, end Position, memory Grid) int {
+	path := FindPathWithObstacles(start, end, memory)
+	if path == nil {
+		return math.MaxInt
+	}
+	return len(path) - 1
 }
 
-func FindShortestPathWithObstacles(start, end Position, grid Grid) int {
-	scores := make([][]int, grid.Width)
-	for i := range scores {
-		scores[i] = make([]int, grid.Height)
-		for j := range scores[i] {
-			if i == start.X && j == start.Y {
-				scores[i][j] = 0
-			} else {
-				scores[i][j] = math.MaxInt
-			}
-		}
+// Returns a shortest path from start to end, both included, or nil if the
+// obstacles block the way.
+func FindPathWithObstacles(start, end Position, memory Grid) []Position {
+	blocked := grid.New(memory.Width, memory.Height, false)
+	for _, pos := range memory.Obstacles {
+		blocked.Set(pos, true)
 	}
 
-	queue := []Position{start}
-
-	for len(queue) > 0 {
-		next := queue[0]
-		queue = queue[1:]
-
-		for _, dir := range ALL_DIRS {
-			point := next.MoveAlong(dir)
-			if !grid.IsInBounds(point) || slices.Contains(grid.Obstacles, point) {
-				continue
-			}
-
-			curScore := scores[point.X][point.Y]
-			newScore := scores[next.X][next.Y] + 1
-			if curScore > newScore {
-				queue = append(queue, point)
-				scores[point.X][point.Y] = newScore
+	neighbours := func(pos Position) []Position {
+		next := make([]Position, 0, 4)
+		for _, point := range blocked.Neighbours4(pos) {
+			if !blocked.At(point) {
+				next = append(next, point)
 			}
 		}
+		return next
 	}
-
-	return scores[end.X][end.Y]
+	heuristic := func(pos Position) int {
+		return grid.ManhattanDistance(pos, end)
+	}
+	return search.AStar(start, end, search.Unit(neighbours), heuristic).Path(end)
 }
 
-func ReadInput(input string, width, height int) Grid {
-	lines := strings.Split(input, "\n")
-	grid := Grid{width, height, make([]Position, len(lines))}
+func ReadInput(input string, width, height int) (Grid, error) {
+	lines := parse.Lines(input)
+	grid := Grid{Size{Width: width, Height: height}, make([]Position, len(lines))}
 	for i := range grid.Obstacles {
 		pos := Position{}
-		fmt.Sscanf(lines[i], "%d,%d", &pos.X, &pos.Y)
+		if err := parse.Sscanf(lines[i], i+1, "%d,%d", &pos.X, &pos.Y); err != nil {
+			return Grid{}, err
+		}
+		if !grid.IsInBounds(pos) {
+			return Grid{}, parse.Errorf(i+1, 0, "byte %v is outside of the %dx%d memory space", pos, width, height)
+		}
 		grid.Obstacles[i] = pos
 	}
 
-	return grid
+	return grid, nil
 }
 
-func main() {
-	grid := ReadInput(input, 71, 71)
-	start := Position{0, 0}
-	end := Position{grid.Width - 1, grid.Height - 1}
+func SolvePart1(grid Grid, fallen int) int {
+	start := Position{X: 0, Y: 0}
+	end := Position{X: grid.Width - 1, Y: grid.Height - 1}
+	return FindShortestPathWithObstacles(start, end, Grid{grid.Size, grid.Obstacles[:fallen]})
+}
 
-	newGrid := Grid{grid.Width, grid.Height, grid.Obstacles[:1024]}
-	fmt.Println("Part 1 Solution:", FindShortestPathWithObstacles(start, end, newGrid))
+// Returns the first obstacle after which the end cannot be reached anymore.
+// The first [fallen] obstacles are known to keep the path open. A new path is
+// only searched for when an obstacle falls on the current one.
+func SolvePart2(grid Grid, fallen int) Position {
+	start := Position{X: 0, Y: 0}
+	end := Position{X: grid.Width - 1, Y: grid.Height - 1}
 
-	for i := range grid.Obstacles {
-		if i <= 1024 {
+	path := FindPathWithObstacles(start, end, Grid{grid.Size, grid.Obstacles[:fallen]})
+	for i := fallen + 1; i <= len(grid.Obstacles) && path != nil; i++ {
+		if !slices.Contains(path, grid.Obstacles[i-1]) {
 			continue
 		}
-		newGrid := Grid{grid.Width, grid.Height, grid.Obstacles[:i]}
-		shortest := FindShortestPathWithObstacles(start, end, newGrid)
-		if shortest == math.MaxInt {
-			fmt.Println("Part 2 Solution:", grid.Obstacles[len(newGrid.Obstacles)-1])
-			break
+		path = FindPathWithObstacles(start, end, Grid{grid.Size, grid.Obstacles[:i]})
+		if path == nil {
+			return grid.Obstacles[i-1]
 		}
+	}
+	return Position{X: -1, Y: -1}
+}
+
+// Draws the memory space as the bytes fall, one frame per byte.
+func Animate(ctx context.Context, input string, observer trace.Observer[grid.Grid[rune]]) error {
+	memory, err := ReadInput(input, 71, 71)
+	if err != nil {
+		return err
+	}
 
-		fmt.Println(i, shortest)
+	frame := grid.New(memory.Width, memory.Height, '.')
+	for i, pos := range memory.Obstacles {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		frame.Set(pos, '#')
+		observer.Observe(trace.Step[grid.Grid[rune]]{Index: i, Action: fmt.Sprintf("byte falls at %v", pos), State: frame.Copy()})
 	}
+	return nil
+}
+
+func init() {
+	registry.Register(registry.Day{
+		Number: 18,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			grid, err := ReadInput(input, 71, 71)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart1(grid, min(1024, len(grid.Obstacles))), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			grid, err := ReadInput(input, 71, 71)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart2(grid, min(1024, len(grid.Obstacles))), nil
+		},
+		Generate: GenerateInput,
+	})
+	animate.Register(18, Animate)
 }
@@ -1,8 +1,16 @@
-package main
+package day18
 
 import (
+	"context"
 	"math"
+	"reflect"
+	"slices"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
 )
 
 func TestFindShortestPath(t *testing.T) {
@@ -14,32 +22,32 @@ func TestFindShortestPath(t *testing.T) {
 	}{
 		{
 			Name:  "shortest is 0 if start and end are same",
-			Start: Position{1, 2},
-			End:   Position{1, 2},
+			Start: Position{X: 1, Y: 2},
+			End:   Position{X: 1, Y: 2},
 			Want:  0,
 		},
 		{
 			Name:  "shortest is change in x if start and end are in same line",
-			Start: Position{1, 2},
-			End:   Position{5, 2},
+			Start: Position{X: 1, Y: 2},
+			End:   Position{X: 5, Y: 2},
 			Want:  4,
 		},
 		{
 			Name:  "shortest is abs of change in x if start and end are in same line",
-			Start: Position{5, 2},
-			End:   Position{1, 2},
+			Start: Position{X: 5, Y: 2},
+			End:   Position{X: 1, Y: 2},
 			Want:  4,
 		},
 		{
 			Name:  "shortest is change in y if start and end are in same line vertically",
-			Start: Position{3, 2},
-			End:   Position{3, 5},
+			Start: Position{X: 3, Y: 2},
+			End:   Position{X: 3, Y: 5},
 			Want:  3,
 		},
 		{
 			Name:  "shortest is sum of xDiff and yDiff",
-			Start: Position{1, 2},
-			End:   Position{4, 6},
+			Start: Position{X: 1, Y: 2},
+			End:   Position{X: 4, Y: 6},
 			Want:  7,
 		},
 	}
@@ -64,42 +72,42 @@ func TestFindShortestPathWithObstacles(t *testing.T) {
 	}{
 		{
 			Name:      "use straight line or L shape if no obstacles in middle",
-			Start:     Position{0, 0},
-			End:       Position{5, 0},
-			Obstacles: []Position{{2, 3}, {4, 5}},
+			Start:     Position{X: 0, Y: 0},
+			End:       Position{X: 5, Y: 0},
+			Obstacles: []Position{{X: 2, Y: 3}, {X: 4, Y: 5}},
 			Want:      5,
 		},
 		{
 			Name:      "infinity if cannot reach the point",
-			Start:     Position{0, 0},
-			End:       Position{5, 0},
-			Obstacles: []Position{{1, 0}, {0, 1}},
+			Start:     Position{X: 0, Y: 0},
+			End:       Position{X: 5, Y: 0},
+			Obstacles: []Position{{X: 1, Y: 0}, {X: 0, Y: 1}},
 			Want:      math.MaxInt,
 		},
 		{
 			Name:      "move around the obstacle if only single",
-			Start:     Position{0, 0},
-			End:       Position{5, 0},
-			Obstacles: []Position{{1, 0}},
+			Start:     Position{X: 0, Y: 0},
+			End:       Position{X: 5, Y: 0},
+			Obstacles: []Position{{X: 1, Y: 0}},
 			Want:      7,
 		},
 		{
 			Name:  "example input",
-			Start: Position{0, 0},
-			End:   Position{6, 6},
+			Start: Position{X: 0, Y: 0},
+			End:   Position{X: 6, Y: 6},
 			Obstacles: []Position{
-				{5, 4},
-				{4, 2},
-				{4, 5},
-				{3, 0},
-				{2, 1},
-				{6, 3},
-				{2, 4},
-				{1, 5},
-				{0, 6},
-				{3, 3},
-				{2, 6},
-				{5, 1},
+				{X: 5, Y: 4},
+				{X: 4, Y: 2},
+				{X: 4, Y: 5},
+				{X: 3, Y: 0},
+				{X: 2, Y: 1},
+				{X: 6, Y: 3},
+				{X: 2, Y: 4},
+				{X: 1, Y: 5},
+				{X: 0, Y: 6},
+				{X: 3, Y: 3},
+				{X: 2, Y: 6},
+				{X: 5, Y: 1},
 			},
 			Want: 22,
 		},
@@ -107,7 +115,7 @@ func TestFindShortestPathWithObstacles(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			grid := Grid{7, 7, testcase.Obstacles}
+			grid := Grid{Size{Width: 7, Height: 7}, testcase.Obstacles}
 			shortest := FindShortestPathWithObstacles(testcase.Start, testcase.End, grid)
 			if shortest != testcase.Want {
 				t.Errorf("Got wrong output: got %d, want %d", shortest, testcase.Want)
@@ -115,3 +123,123 @@ func TestFindShortestPathWithObstacles(t *testing.T) {
 		})
 	}
 }
+
+const exampleInput = `5,4
+4,2
+4,5
+3,0
+2,1
+6,3
+2,4
+1,5
+0,6
+3,3
+2,6
+5,1
+1,2
+5,5
+2,5
+6,5
+1,4
+0,4
+6,4
+1,1
+6,1
+1,0
+0,5
+1,6
+2,0`
+
+func TestFindPathWithObstacles(t *testing.T) {
+	memory, err := ReadInput(exampleInput, 7, 7)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	memory.Obstacles = memory.Obstacles[:12]
+	start, end := Position{X: 0, Y: 0}, Position{X: 6, Y: 6}
+
+	path := FindPathWithObstacles(start, end, memory)
+	if len(path) != 23 || path[0] != start || path[len(path)-1] != end {
+		t.Fatalf("Got wrong path: %v", path)
+	}
+	for i := 1; i < len(path); i++ {
+		if grid.ManhattanDistance(path[i-1], path[i]) != 1 || slices.Contains(memory.Obstacles, path[i]) {
+			t.Errorf("Got wrong step from %v to %v", path[i-1], path[i])
+		}
+	}
+}
+
+func TestSolvePart2(t *testing.T) {
+	memory, err := ReadInput(exampleInput, 7, 7)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := Position{X: 6, Y: 1}
+	if got := SolvePart2(memory, 12); got != want {
+		t.Errorf("Got wrong output: got %v, want %v", got, want)
+	}
+}
+
+func TestReadInputErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"line is not a position", "5,4\n4;2", `line 2: "4;2" does not match "%d,%d"`},
+		{"position is outside of memory", "5,4\n4,2\n7,0", "line 3: byte 7,0 is outside of the 7x7 memory space"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := ReadInput(testcase.Input, 7, 7)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestAnimate(t *testing.T) {
+	recorder := &trace.Recorder[grid.Grid[rune]]{}
+	if err := Animate(context.Background(), "1,0\n70,70", recorder); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	wantActions := []string{"byte falls at 1,0", "byte falls at 70,70"}
+	if got := recorder.Actions(); !reflect.DeepEqual(got, wantActions) {
+		t.Errorf("Got wrong actions: got %v, want %v", got, wantActions)
+	}
+	first, last := recorder.Steps[0].State, recorder.Steps[1].State
+	if first.At(Position{X: 1, Y: 0}) != '#' || first.At(Position{X: 70, Y: 70}) != '.' || last.At(Position{X: 70, Y: 70}) != '#' {
+		t.Errorf("Got wrong frames:\n%s\n%s", first, last)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	memory, err := ReadInput(GenerateInput(proptest.NewRand(1)), 71, 71)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if len(memory.Obstacles) != 3450 {
+		t.Errorf("Got wrong number of bytes: got %d, want 3450", len(memory.Obstacles))
+	}
+	if slices.Contains(memory.Obstacles, Position{X: 0, Y: 0}) || slices.Contains(memory.Obstacles, Position{X: 70, Y: 70}) {
+		t.Errorf("Got a byte falling on a corner")
+	}
+	if got := SolvePart1(memory, 1024); got == math.MaxInt {
+		t.Errorf("Got the exit blocked after 1024 bytes")
+	}
+	if got := SolvePart2(memory, 1024); got == (Position{X: -1, Y: -1}) {
+		t.Errorf("Got the exit never blocked")
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 18, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 18, 2)
+}
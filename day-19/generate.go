@@ -0,0 +1,54 @@
+package day19
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+const stripes = "wubrg"
+
+func randomStripes(rng *rand.Rand, length int) string {
+	var builder strings.Builder
+	for range length {
+		builder.WriteByte(stripes[rng.IntN(len(stripes))])
+	}
+	return builder.String()
+}
+
+// Generate returns the given number of distinct towels, of 1 to maxTowel
+// stripes, and of patterns, of up to maxPattern stripes. Half of the patterns
+// are made of towels, so that they can be arranged, while the others are
+// random. There cannot be more towels than the distinct ones of maxTowel
+// stripes.
+func Generate(rng *rand.Rand, towels, maxTowel, patterns, maxPattern int) string {
+	towelList := make([]string, 0, towels)
+	for len(towelList) < towels {
+		towel := randomStripes(rng, 1+rng.IntN(maxTowel))
+		if !slices.Contains(towelList, towel) {
+			towelList = append(towelList, towel)
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(strings.Join(towelList, ", "))
+	builder.WriteString("\n\n")
+	for range patterns {
+		length := 1 + rng.IntN(maxPattern)
+		pattern := randomStripes(rng, length)
+		if rng.IntN(2) == 0 {
+			pattern = ""
+			for len(pattern) < length {
+				pattern += towelList[rng.IntN(len(towelList))]
+			}
+		}
+		builder.WriteString(pattern)
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 447, 8, 400, 60)
+}
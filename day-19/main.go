@@ -1,41 +1,64 @@
-package main
+package day19
 
 import (
-	_ "embed"
-	"fmt"
+	"context"
+	"errors"
 	"strings"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/memo"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parallel"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
 )
 
-//go:embed input.txt
-var input string
+// The number of ways to arrange the patterns and what is left of them,
+// shared by the goroutines counting the patterns.
+type CountCache = memo.Memo[string, int]
+
+func NewCountCache() *CountCache {
+	return memo.New[string, int](memo.Options[string]{Shards: 16, Hash: memo.HashString})
+}
 
-func CountPossibilities(pattern string, towels []string, countMap map[string]int) int {
-	if possible, found := countMap[pattern]; found {
+func CountPossibilities(pattern string, towels []string, cache *CountCache) int {
+	if pattern == "" {
+		return 1
+	}
+	if possible, found := cache.Get(pattern); found {
 		return possible
 	}
 
 	total := 0
 	for _, towel := range towels {
 		if remaining, found := strings.CutPrefix(pattern, towel); found {
-			total += CountPossibilities(remaining, towels, countMap)
+			total += CountPossibilities(remaining, towels, cache)
 		}
 	}
 
-	countMap[pattern] = total
+	cache.Set(pattern, total)
 	return total
 }
 
-func SolveParts(patterns, towels []string) (int, int) {
+// Counts the patterns in parallel, sharing a cache, and returns the number of
+// patterns that can be arranged and the total number of arrangements.
+func SolveParts(ctx context.Context, patterns, towels []string) (int, int, error) {
+	cache := NewCountCache()
+	counts, err := parallel.Map(ctx, patterns, func(ctx context.Context, pattern string) (int, error) {
+		return CountPossibilities(pattern, towels, cache), nil
+	})
+	memo.Report(ctx, "patterns", cache.Stats())
+	if err != nil {
+		return 0, 0, err
+	}
+
 	possible := 0
 	total := 0
-	countMap := map[string]int{"": 1}
-	for _, pattern := range patterns {
-		if possibilities := CountPossibilities(pattern, towels, countMap); possibilities > 0 {
+	for _, possibilities := range counts {
+		if possibilities > 0 {
 			total += possibilities
 			possible++
 		}
 	}
-	return possible, total
+	return possible, total, nil
 }
 
 func GetTowelMap(towels []string) map[byte][]string {
@@ -52,16 +75,69 @@ func GetTowelMap(towels []string) map[byte][]string {
 	return towelMap
 }
 
-func ReadInput(input string) (towels, patterns []string) {
-	towelsInput, remaining, _ := strings.Cut(input, "\n\n")
-	towels = strings.Split(towelsInput, ", ")
-	patterns = strings.Split(remaining, "\n")
-	return
+// Check that the text only has the stripe colors: white (w), blue (u),
+// black (b), red (r) or green (g).
+func checkStripes(text string, line, column int) error {
+	if text == "" {
+		return parse.Errorf(line, column, "want at least one stripe")
+	}
+	for i, char := range text {
+		if !strings.ContainsRune("wubrg", char) {
+			return parse.Errorf(line, column+i, "unexpected %q, want one of %q", char, "wubrg")
+		}
+	}
+	return nil
+}
+
+func ReadInput(input string) (towels, patterns []string, err error) {
+	blocks := parse.Blocks(input)
+	if len(blocks) != 2 || strings.Contains(blocks[0].Text, "\n") {
+		return nil, nil, errors.New("want a line of towels and the patterns separated by a blank line")
+	}
+
+	towels = strings.Split(blocks[0].Text, ", ")
+	column := 1
+	for _, towel := range towels {
+		if err := checkStripes(towel, blocks[0].Line, column); err != nil {
+			return nil, nil, err
+		}
+		column += len(towel) + 2
+	}
+
+	patterns = parse.Lines(blocks[1].Text)
+	for i, pattern := range patterns {
+		if err := checkStripes(pattern, blocks[1].Line+i, 1); err != nil {
+			return nil, nil, err
+		}
+	}
+	return towels, patterns, nil
 }
 
-func main() {
-	towels, patterns := ReadInput(input)
-	part1Sol, part2Sol := SolveParts(patterns, towels)
-	fmt.Println("Part 1 Solution:", part1Sol)
-	fmt.Println("Part 1 Solution:", part2Sol)
+func init() {
+	registry.Register(registry.Day{
+		Number: 19,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			towels, patterns, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			part1Sol, _, err := SolveParts(ctx, patterns, towels)
+			if err != nil {
+				return nil, err
+			}
+			return part1Sol, nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			towels, patterns, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			_, part2Sol, err := SolveParts(ctx, patterns, towels)
+			if err != nil {
+				return nil, err
+			}
+			return part2Sol, nil
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,7 +1,14 @@
-package main
+package day19
 
 import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strings"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
 )
 
 func TestIsThePatternPossible(t *testing.T) {
@@ -51,7 +58,7 @@ func TestIsThePatternPossible(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			got := CountPossibilities(testcase.Pattern, testcase.Towels, map[string]int{"": 1}) > 0
+			got := CountPossibilities(testcase.Pattern, testcase.Towels, NewCountCache()) > 0
 			if got != testcase.Want {
 				t.Errorf("Got wrong output: got %v, want %v", got, testcase.Want)
 			}
@@ -71,8 +78,14 @@ bwurrg
 brgr
 bbrgwb`
 
-	towels, patterns := ReadInput(input)
-	got, _ := SolveParts(patterns, towels)
+	towels, patterns, err := ReadInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	got, _, err := SolveParts(context.Background(), patterns, towels)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	want := 6
 
 	if got != want {
@@ -127,7 +140,7 @@ func TestCountPossibilities(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			got := CountPossibilities(testcase.Pattern, testcase.Towels, map[string]int{"": 1})
+			got := CountPossibilities(testcase.Pattern, testcase.Towels, NewCountCache())
 			if got != testcase.Want {
 				t.Errorf("Got wrong output: got %d, want %d", got, testcase.Want)
 			}
@@ -147,11 +160,91 @@ bwurrg
 brgr
 bbrgwb`
 
-	towels, patterns := ReadInput(input)
-	_, got := SolveParts(patterns, towels)
+	towels, patterns, err := ReadInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	_, got, err := SolveParts(context.Background(), patterns, towels)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	want := 16
 
 	if got != want {
 		t.Errorf("Got wrong output: got %d, want %d", got, want)
 	}
 }
+
+func TestReadInputErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"unknown stripe in towel", "r, wr, bx\n\nbrwrr", `line 1, column 9: unexpected 'x', want one of "wubrg"`},
+		{"empty towel", "r, , b\n\nbrwrr", "line 1, column 4: want at least one stripe"},
+		{"unknown stripe in pattern", "r, wr, b\n\nbrwrr\nbggr\ngbbry", `line 5, column 5: unexpected 'y', want one of "wubrg"`},
+		{"no patterns", "r, wr, b\n", "want a line of towels and the patterns separated by a blank line"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, _, err := ReadInput(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+// Counts the arrangements by trying every towel at every position, without
+// remembering the counts of the patterns already seen.
+func bruteForceCount(pattern string, towels []string) int {
+	if pattern == "" {
+		return 1
+	}
+	count := 0
+	for _, towel := range towels {
+		if strings.HasPrefix(pattern, towel) {
+			count += bruteForceCount(pattern[len(towel):], towels)
+		}
+	}
+	return count
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 2+rng.IntN(8), 3, 5, 12) }
+	proptest.CheckParts(t, 19, generate, func(input string) (any, any) {
+		towels, patterns, err := ReadInput(input)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+
+		possible, total := 0, 0
+		for _, pattern := range patterns {
+			if count := bruteForceCount(pattern, towels); count > 0 {
+				possible++
+				total += count
+			}
+		}
+		return possible, total
+	})
+}
+
+func TestSolvePartsStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := SolveParts(ctx, []string{"brwrr", "bggr"}, []string{"r", "b", "g", "wr", "br"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Got wrong error: got %v, want %v", err, context.Canceled)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 19, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 19, 2)
+}
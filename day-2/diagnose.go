@@ -0,0 +1,129 @@
+package day2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Diagnostic explains whether a report is safe, and if not, why.
+type Diagnostic struct {
+	Line   int   `json:"line"`
+	Levels []int `json:"levels"`
+	Safe   bool  `json:"safe"`
+	// The first pair of adjacent levels breaking the policy, for an unsafe
+	// report.
+	Violation *Violation `json:"violation,omitempty"`
+}
+
+type Violation struct {
+	// Position of the first level of the pair, counted from 1.
+	Position int    `json:"position"`
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+	Reason   string `json:"reason"`
+}
+
+// Diagnose checks every report of the input against the policy, without
+// removing any level.
+func (p SafetyPolicy) Diagnose(input string) ([]Diagnostic, error) {
+	reports, err := ReadReports(input)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostics := make([]Diagnostic, len(reports))
+	for i, values := range reports {
+		diagnostics[i] = p.DiagnoseReport(i+1, values)
+	}
+	return diagnostics, nil
+}
+
+// DiagnoseReport finds the first pair of adjacent levels of a report breaking
+// the policy. When either direction is allowed, the first change between two
+// different levels decides it.
+func (p SafetyPolicy) DiagnoseReport(line int, values []int) Diagnostic {
+	diagnostic := Diagnostic{Line: line, Levels: values, Safe: true}
+	sign := 0
+	switch p.Direction {
+	case Increasing:
+		sign = 1
+	case Decreasing:
+		sign = -1
+	}
+
+	for i := 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		if sign == 0 && change != 0 {
+			sign = change / abs(change)
+		}
+		if p.allows(change, sign) {
+			continue
+		}
+
+		diagnostic.Safe = false
+		diagnostic.Violation = &Violation{
+			Position: i,
+			From:     values[i-1],
+			To:       values[i],
+			Reason:   p.reason(change, sign),
+		}
+		break
+	}
+	return diagnostic
+}
+
+// Explains why a change breaks the policy.
+func (p SafetyPolicy) reason(change, sign int) string {
+	switch {
+	case change == 0:
+		return "levels are equal"
+	case change*sign < 0 && p.Direction == Either:
+		return "changes direction"
+	case change < 0 && sign > 0:
+		return "decreases in an increasing report"
+	case change > 0 && sign < 0:
+		return "increases in a decreasing report"
+	case abs(change) < p.MinStep:
+		return fmt.Sprintf("changes by %d, less than %d", abs(change), p.MinStep)
+	default:
+		return fmt.Sprintf("changes by %d, more than %d", abs(change), p.MaxStep)
+	}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// WriteTable writes a line for every diagnostic, with the first pair breaking
+// the policy and the reason for the unsafe reports.
+func WriteTable(w io.Writer, diagnostics []Diagnostic) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "line\tsafe\tlevels\tposition\tpair\treason")
+	for _, d := range diagnostics {
+		levels := strings.Trim(fmt.Sprint(d.Levels), "[]")
+		if d.Violation == nil {
+			fmt.Fprintf(tw, "%d\tyes\t%s\t-\t-\t-\n", d.Line, levels)
+			continue
+		}
+		v := d.Violation
+		fmt.Fprintf(tw, "%d\tno\t%s\t%d\t%d %d\t%s\n", d.Line, levels, v.Position, v.From, v.To, v.Reason)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the diagnostics as an indented JSON array, which is empty
+// rather than null when there are no diagnostics.
+func WriteJSON(w io.Writer, diagnostics []Diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diagnostics)
+}
@@ -0,0 +1,44 @@
+package day2
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Generate returns the given number of reports of 5 to 8 levels from 1 to 99.
+// Most reports change by 1 to 3 levels at every step, as the safe reports do,
+// with a bad step or two in some of them, so that every part has safe and
+// unsafe reports.
+func Generate(rng *rand.Rand, reports int) string {
+	var builder strings.Builder
+	for range reports {
+		levels := make([]int, 5+rng.IntN(4))
+		sign := 1 - 2*rng.IntN(2)
+		levels[0] = 10 + rng.IntN(80)
+		for i := 1; i < len(levels); i++ {
+			step := sign * (1 + rng.IntN(3))
+			switch rng.IntN(10) {
+			case 0:
+				step = 0
+			case 1:
+				step = -step
+			case 2:
+				step *= 3
+			}
+			levels[i] = min(max(levels[i-1]+step, 1), 99)
+		}
+
+		texts := make([]string, len(levels))
+		for i, level := range levels {
+			texts[i] = strconv.Itoa(level)
+		}
+		builder.WriteString(strings.Join(texts, " ") + "\n")
+	}
+	return builder.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 1000)
+}
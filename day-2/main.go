@@ -1,107 +1,117 @@
-package main
+package day2
 
 import (
-	_ "embed"
-	"fmt"
-	"strconv"
-	"strings"
-)
+	"context"
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
-func getValuesFromLine(line string) []int {
-	nums := strings.Split(line, " ")
-	values := make([]int, len(nums))
-	for i, num := range nums {
-		value, err := strconv.ParseInt(num, 10, 32)
-		if err == nil {
-			values[i] = int(value)
-		} else {
-			fmt.Println(err)
+// Read the levels of each report, one report per line.
+func ReadReports(input string) ([][]int, error) {
+	lines := parse.Lines(input)
+	reports := make([][]int, len(lines))
+	for i, line := range lines {
+		values, err := parse.Ints(line, i+1)
+		if err != nil {
+			return nil, err
 		}
+		reports[i] = values
 	}
-	return values
-}
-
-func getChanges(values []int) []int {
-	changes := make([]int, len(values)-1)
-	for i := 0; i < len(values)-1; i++ {
-		changes[i] = values[i+1] - values[i]
-	}
-	return changes
+	return reports, nil
 }
 
-func getSign(value int) int {
-	if value > 0 {
-		return 1
-	}
-	return -1
+// SafeWithRemovals tells whether removing at most k levels makes a report
+// safe under the rules of the puzzle, and returns the indices of the fewest
+// levels to remove, in increasing order.
+func SafeWithRemovals(values []int, k int) ([]int, bool) {
+	return DefaultPolicy.SafeWithRemovals(values, k)
 }
 
-func areValuesSafe(values []int) bool {
-	changes := getChanges(values)
-	if len(changes) == 0 {
-		return true
+// SafeWithRemovals tells whether removing at most k levels makes a report
+// safe under the policy, and returns the indices of the fewest levels to
+// remove, in increasing order.
+//
+// For each direction, it finds the fewest levels to remove so that the levels
+// up to each index are safe with the level of that index kept, from the
+// previous level kept. With at most k levels removed, that level is one of the
+// k+1 before, so it takes O(n*k) steps rather than trying every combination.
+func (p SafetyPolicy) SafeWithRemovals(values []int, k int) ([]int, bool) {
+	n := len(values)
+	if n == 0 {
+		return nil, true
 	}
 
-	sign := getSign(changes[0])
-	for _, change := range changes {
-		change = change * sign
-		if change <= 0 || change > 3 {
-			return false
+	best, bestEnd := k+1, 0
+	var bestPrevious []int
+	for _, sign := range p.Direction.signs() {
+		// The fewest levels removed before each kept level, and the previous
+		// level kept, or -1 if it is the first.
+		removals := make([]int, n)
+		previous := make([]int, n)
+		for i := range values {
+			removals[i], previous[i] = i, -1
+			for j := max(i-k-1, 0); j < i; j++ {
+				count := removals[j] + i - j - 1
+				if count < removals[i] && p.allows(values[i]-values[j], sign) {
+					removals[i], previous[i] = count, j
+				}
+			}
+			if count := removals[i] + n - 1 - i; count < best {
+				best, bestEnd, bestPrevious = count, i, previous
+			}
 		}
 	}
-	return true
-}
-
-func isLineSafe(line string) bool {
-	values := getValuesFromLine(line)
-	return areValuesSafe(values)
-}
-
-func getRemovedList(values []int, index int) []int {
-	newValues := make([]int, 0, len(values)-1)
-	for i, value := range values {
-		if i == index {
-			continue
-		}
-		newValues = append(newValues, value)
+	if best > k {
+		return nil, false
 	}
-	return newValues
-}
 
-func isLineSafeWithRemove(line string) bool {
-	values := getValuesFromLine(line)
-
-	if areValuesSafe(values) {
-		return true
+	kept := make([]bool, n)
+	for i := bestEnd; i != -1; i = bestPrevious[i] {
+		kept[i] = true
 	}
-
-	for i := range values {
-		removedList := getRemovedList(values, i)
-		if areValuesSafe(removedList) {
-			return true
+	removed := make([]int, 0, best)
+	for i, isKept := range kept {
+		if !isKept {
+			removed = append(removed, i)
 		}
 	}
+	return removed, true
+}
 
-	return false
+// SafeReportCount counts the reports that are safe under the rules of the
+// puzzle once at most k of their levels are removed.
+func SafeReportCount(input string, k int) (int, error) {
+	return DefaultPolicy.SafeReportCount(input, k)
 }
 
-func SafeReportCount(input string, safeFn func(string) bool) int {
-	lines := strings.Split(input, "\n")
+// SafeReportCount counts the reports that are safe under the policy once at
+// most k of their levels are removed.
+func (p SafetyPolicy) SafeReportCount(input string, k int) (int, error) {
+	reports, err := ReadReports(input)
+	if err != nil {
+		return 0, err
+	}
 
 	count := 0
-	for _, line := range lines {
-		if safeFn(line) {
+	for _, values := range reports {
+		if _, safe := p.SafeWithRemovals(values, k); safe {
 			count += 1
 		}
 	}
 
-	return count
+	return count, nil
 }
 
-func main() {
-	fmt.Println("Answer for part 1:", SafeReportCount(input, isLineSafe))
-	fmt.Println("Answer for part 2:", SafeReportCount(input, isLineSafeWithRemove))
+func init() {
+	registry.Register(registry.Day{
+		Number: 2,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			return SafeReportCount(input, 0)
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			return SafeReportCount(input, 1)
+		},
+		Generate: GenerateInput,
+	})
 }
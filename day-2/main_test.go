@@ -1,6 +1,16 @@
-package main
+package day2
 
-import "testing"
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
+)
 
 func TestSafeReportCount(t *testing.T) {
 	testcases := []struct {
@@ -29,7 +39,10 @@ func TestSafeReportCount(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			safeCount := SafeReportCount(testcase.Input, isLineSafe)
+			safeCount, err := SafeReportCount(testcase.Input, 0)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
 			if safeCount != testcase.Want {
 				t.Errorf("Got wront output: got %d, want %d", safeCount, testcase.Want)
 			}
@@ -38,13 +51,15 @@ func TestSafeReportCount(t *testing.T) {
 }
 
 func BenchmarkSafeReportCount(b *testing.B) {
+	input := testutil.LoadOrSkip(b, 2)
 	for i := 0; i < b.N; i++ {
-		SafeReportCount(input, isLineSafe)
+		SafeReportCount(input, 0)
 	}
 }
 func BenchmarkSafeReportCountPart2(b *testing.B) {
+	input := testutil.LoadOrSkip(b, 2)
 	for i := 0; i < b.N; i++ {
-		SafeReportCount(input, isLineSafeWithRemove)
+		SafeReportCount(input, 1)
 	}
 }
 
@@ -73,7 +88,11 @@ func TestIsLineSafeWithRemove(t *testing.T) {
 	}
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			isSafe := isLineSafeWithRemove(testcase.Input)
+			values, err := parse.Ints(testcase.Input, 1)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			_, isSafe := SafeWithRemovals(values, 1)
 			if isSafe != testcase.IsSafe {
 				t.Errorf("Got wrong output: got %v, want %v", isSafe, testcase.IsSafe)
 			}
@@ -81,3 +100,301 @@ func TestIsLineSafeWithRemove(t *testing.T) {
 	}
 
 }
+
+func TestSafeReportCountErrors(t *testing.T) {
+	_, err := SafeReportCount("1 2 3\n4 5 six", 0)
+	if want := `line 2, column 5: invalid number "six"`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func TestSafeWithRemovals(t *testing.T) {
+	testcases := []struct {
+		Name        string
+		Input       string
+		K           int
+		WantRemoved []int
+		WantSafe    bool
+	}{
+		{"safe report needs no removal", "7 6 4 2 1", 0, []int{}, true},
+		{"unsafe report without removals", "1 3 2 4 5", 0, nil, false},
+		{"single bad level is removed", "1 2 9 3 4", 1, []int{2}, true},
+		{"first level is removed", "9 1 2 3", 1, []int{0}, true},
+		{"last level is removed", "1 2 3 9", 1, []int{3}, true},
+		{"two bad levels need two removals", "1 9 2 9 3", 1, nil, false},
+		{"two bad levels are removed", "1 9 2 9 3", 2, []int{1, 3}, true},
+		{"consecutive bad levels are removed", "1 2 8 9 3 4", 2, []int{2, 3}, true},
+		{"direction is chosen after the removals", "5 6 4 3 2", 1, []int{1}, true},
+		{"all but one level are removed", "1 1 1 1", 3, []int{1, 2, 3}, true},
+		{"empty report is safe", "", 0, []int{}, true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			values, err := parse.Ints(testcase.Input, 1)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			removed, safe := SafeWithRemovals(values, testcase.K)
+			if safe != testcase.WantSafe || !slices.Equal(removed, testcase.WantRemoved) {
+				t.Errorf("Got wrong output: got %v %v, want %v %v", removed, safe, testcase.WantRemoved, testcase.WantSafe)
+			}
+		})
+	}
+}
+
+// Finds the fewest levels to remove by trying every combination of levels,
+// or -1 if removing more than k levels would be needed.
+func bruteForceRemovals(values []int, k int) int {
+	for count := 0; count <= min(k, len(values)); count++ {
+		if canRemove(values, count) {
+			return count
+		}
+	}
+	return -1
+}
+
+// Tells whether removing exactly count levels can make the report safe.
+func canRemove(values []int, count int) bool {
+	if count == 0 {
+		return isSafe(values)
+	}
+	for i := range values {
+		rest := slices.Delete(slices.Clone(values), i, i+1)
+		if canRemove(rest, count-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSafe(values []int) bool {
+	for _, sign := range []int{1, -1} {
+		safe := true
+		for i := 1; i < len(values); i++ {
+			change := (values[i] - values[i-1]) * sign
+			safe = safe && change >= 1 && change <= 3
+		}
+		if safe {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSafeWithRemovalsAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(20)) }
+	proptest.Check(t, generate, func(input string) error {
+		reports, err := ReadReports(input)
+		if err != nil {
+			return err
+		}
+		for _, values := range reports {
+			for k := range 4 {
+				removed, safe := SafeWithRemovals(values, k)
+				want := bruteForceRemovals(values, k)
+				if safe != (want >= 0) || safe && len(removed) != want {
+					return fmt.Errorf("report %v with k=%d: got %v %v, want %d removals", values, k, removed, safe, want)
+				}
+				kept := slices.Clone(values)
+				for i := len(removed) - 1; i >= 0; i-- {
+					kept = slices.Delete(kept, removed[i], removed[i]+1)
+				}
+				if safe && !isSafe(kept) {
+					return fmt.Errorf("report %v with k=%d: removing %v leaves unsafe %v", values, k, removed, kept)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func TestSafetyPolicies(t *testing.T) {
+	testcases := []struct {
+		Name   string
+		Policy SafetyPolicy
+		Input  string
+		K      int
+		Want   int
+	}{
+		{"rules of the puzzle", DefaultPolicy, "1 2 4\n4 2 1\n1 1 2\n1 5 6", 0, 2},
+		{"only increasing", SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: Increasing}, "1 2 4\n4 2 1", 0, 1},
+		{"only decreasing", SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: Decreasing}, "1 2 4\n4 2 1", 0, 1},
+		{"larger steps", SafetyPolicy{MinStep: 2, MaxStep: 5}, "1 3 8\n1 2 4\n8 3 1", 0, 2},
+		{"plateaus", SafetyPolicy{MinStep: 1, MaxStep: 3, AllowPlateaus: true}, "1 1 2\n3 3 3\n3 3 2 2\n1 2 2 1", 0, 3},
+		{"plateaus with a removal", SafetyPolicy{MinStep: 1, MaxStep: 3, AllowPlateaus: true}, "1 2 2 1\n1 2 1 0", 1, 2},
+		{"increasing with a removal", SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: Increasing}, "1 0 2 3\n3 2 1", 1, 1},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got, err := testcase.Policy.SafeReportCount(testcase.Input, testcase.K)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if got != testcase.Want {
+				t.Errorf("Got wrong output: got %d, want %d", got, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestSafetyPolicyErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Policy  SafetyPolicy
+		WantErr string
+	}{
+		{"negative min step", SafetyPolicy{MinStep: -1, MaxStep: 3}, "min step -1 is negative"},
+		{"max step below min step", SafetyPolicy{MinStep: 4, MaxStep: 3}, "max step 3 is less than min step 4 or 1"},
+		{"max step of 0", SafetyPolicy{MinStep: 0, MaxStep: 0}, "max step 0 is less than min step 0 or 1"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			err := testcase.Policy.Validate()
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+
+	_, err := ParseDirection("up")
+	if want := `unknown direction "up", want "either", "increasing" or "decreasing"`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func TestDiagnoseReport(t *testing.T) {
+	increasing := SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: Increasing}
+	testcases := []struct {
+		Name   string
+		Policy SafetyPolicy
+		Input  string
+		Want   *Violation
+	}{
+		{"safe report", DefaultPolicy, "7 6 4 2 1", nil},
+		{"large increase", DefaultPolicy, "1 2 7 8 9", &Violation{2, 2, 7, "changes by 5, more than 3"}},
+		{"change of direction", DefaultPolicy, "1 3 2 4 5", &Violation{2, 3, 2, "changes direction"}},
+		{"plateau", DefaultPolicy, "8 6 4 4 1", &Violation{3, 4, 4, "levels are equal"}},
+		{"plateau first", DefaultPolicy, "4 4 3", &Violation{1, 4, 4, "levels are equal"}},
+		{"plateau does not decide the direction", SafetyPolicy{MinStep: 1, MaxStep: 3, AllowPlateaus: true}, "4 4 3 5", &Violation{3, 3, 5, "changes direction"}},
+		{"decrease in an increasing report", increasing, "5 4 6", &Violation{1, 5, 4, "decreases in an increasing report"}},
+		{"increase in a decreasing report", SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: Decreasing}, "5 4 6", &Violation{2, 4, 6, "increases in a decreasing report"}},
+		{"small step", SafetyPolicy{MinStep: 2, MaxStep: 3}, "1 3 4", &Violation{2, 3, 4, "changes by 1, less than 2"}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			values, err := parse.Ints(testcase.Input, 1)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			got := testcase.Policy.DiagnoseReport(1, values)
+			if got.Safe != (testcase.Want == nil) {
+				t.Errorf("Got wrong safety: got %v, want %v", got.Safe, testcase.Want == nil)
+			}
+			if (got.Violation == nil) != (testcase.Want == nil) || got.Violation != nil && *got.Violation != *testcase.Want {
+				t.Errorf("Got wrong violation: got %+v, want %+v", got.Violation, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsOutput(t *testing.T) {
+	diagnostics, err := DefaultPolicy.Diagnose("7 6 4 2 1\n1 2 7 8 9")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	var table bytes.Buffer
+	if err := WriteTable(&table, diagnostics); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	wantTable := `line  safe  levels     position  pair  reason
+1     yes   7 6 4 2 1  -         -     -
+2     no    1 2 7 8 9  2         2 7   changes by 5, more than 3
+`
+	if table.String() != wantTable {
+		t.Errorf("Got wrong table:\n%s\nwant:\n%s", table.String(), wantTable)
+	}
+
+	var json bytes.Buffer
+	if err := WriteJSON(&json, diagnostics); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	wantJSON := `[
+  {
+    "line": 1,
+    "levels": [
+      7,
+      6,
+      4,
+      2,
+      1
+    ],
+    "safe": true
+  },
+  {
+    "line": 2,
+    "levels": [
+      1,
+      2,
+      7,
+      8,
+      9
+    ],
+    "safe": false,
+    "violation": {
+      "position": 2,
+      "from": 2,
+      "to": 7,
+      "reason": "changes by 5, more than 3"
+    }
+  }
+]
+`
+	if json.String() != wantJSON {
+		t.Errorf("Got wrong JSON:\n%s\nwant:\n%s", json.String(), wantJSON)
+	}
+
+	json.Reset()
+	if err := WriteJSON(&json, nil); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if json.String() != "[]\n" {
+		t.Errorf("Got wrong JSON without diagnostics: got %q, want %q", json.String(), "[]\n")
+	}
+}
+
+func TestDiagnoseAgreesWithRemovals(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(20)) }
+	policies := []SafetyPolicy{
+		DefaultPolicy,
+		{MinStep: 1, MaxStep: 3, Direction: Increasing},
+		{MinStep: 1, MaxStep: 3, Direction: Decreasing, AllowPlateaus: true},
+		{MinStep: 2, MaxStep: 6, AllowPlateaus: true},
+	}
+	proptest.Check(t, generate, func(input string) error {
+		for _, policy := range policies {
+			diagnostics, err := policy.Diagnose(input)
+			if err != nil {
+				return err
+			}
+			for _, d := range diagnostics {
+				if _, safe := policy.SafeWithRemovals(d.Levels, 0); safe != d.Safe {
+					return fmt.Errorf("report %v with %+v: diagnosed safe %v, got %v without removals", d.Levels, policy, d.Safe, safe)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 2, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 2, 2)
+}
@@ -0,0 +1,83 @@
+package day2
+
+import (
+	"fmt"
+)
+
+// Direction is the way the levels of a safe report must go.
+type Direction int
+
+const (
+	// Either direction, as long as all the levels go the same way.
+	Either Direction = iota
+	Increasing
+	Decreasing
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Increasing:
+		return "increasing"
+	case Decreasing:
+		return "decreasing"
+	default:
+		return "either"
+	}
+}
+
+func ParseDirection(text string) (Direction, error) {
+	for _, d := range []Direction{Either, Increasing, Decreasing} {
+		if text == d.String() {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf(`unknown direction %q, want "either", "increasing" or "decreasing"`, text)
+}
+
+// Returns the signs of the changes of a safe report: 1 for an increasing
+// report and -1 for a decreasing one.
+func (d Direction) signs() []int {
+	switch d {
+	case Increasing:
+		return []int{1}
+	case Decreasing:
+		return []int{-1}
+	default:
+		return []int{1, -1}
+	}
+}
+
+// SafetyPolicy holds the rules a report must follow to be safe.
+type SafetyPolicy struct {
+	// Smallest and largest change between two adjacent levels, in the
+	// direction of the report.
+	MinStep, MaxStep int
+	Direction        Direction
+	// Whether two adjacent levels can be equal, whatever the smallest change.
+	// Equal levels do not decide the direction of a report.
+	AllowPlateaus bool
+}
+
+// DefaultPolicy holds the rules of the puzzle: the levels all increase or all
+// decrease, by 1 to 3 at every step.
+var DefaultPolicy = SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: Either}
+
+func (p SafetyPolicy) Validate() error {
+	if p.MinStep < 0 {
+		return fmt.Errorf("min step %d is negative", p.MinStep)
+	}
+	if p.MaxStep < max(p.MinStep, 1) {
+		return fmt.Errorf("max step %d is less than min step %d or 1", p.MaxStep, p.MinStep)
+	}
+	return nil
+}
+
+// Tells whether a change between two adjacent levels is safe in a report
+// going the way of the sign.
+func (p SafetyPolicy) allows(change, sign int) bool {
+	if change == 0 {
+		return p.AllowPlateaus
+	}
+	change *= sign
+	return change >= p.MinStep && change <= p.MaxStep
+}
@@ -0,0 +1,22 @@
+package day22
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Generate returns the initial secret numbers of the given number of buyers,
+// below 2^24 as the secrets are pruned to it.
+func Generate(rng *rand.Rand, buyers int) string {
+	secrets := make([]string, buyers)
+	for i := range secrets {
+		secrets[i] = strconv.Itoa(1 + rng.IntN(1<<24-1))
+	}
+	return strings.Join(secrets, "\n") + "\n"
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 1500)
+}
@@ -1,13 +1,12 @@
-package main
+package day22
 
 import (
-	_ "embed"
-	"fmt"
-	"strings"
-)
+	"context"
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/memo"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
 func GenerateNextPseudoRandomNumber(num int) int {
 	secret := num
@@ -73,10 +72,16 @@ func FindThePriceWithChanges(prices []int, changes [4]int) int {
 	return -1
 }
 
-func GetTotalOfPricesWithChangeFn(pricesList [][]int) func(changes [4]int) int {
-	cache := make(map[[4]int]int)
+// The total price of every sequence of changes already looked at.
+type PriceCache = memo.Memo[[4]int, int]
+
+func NewPriceCache() *PriceCache {
+	return memo.New[[4]int, int](memo.Options[[4]int]{})
+}
+
+func GetTotalOfPricesWithChangeFn(pricesList [][]int, cache *PriceCache) func(changes [4]int) int {
 	return func(changes [4]int) int {
-		if total, found := cache[changes]; found {
+		if total, found := cache.Get(changes); found {
 			return total
 		}
 		total := 0
@@ -88,22 +93,27 @@ func GetTotalOfPricesWithChangeFn(pricesList [][]int) func(changes [4]int) int {
 			}
 		}
 
-		cache[changes] = total
+		cache.Set(changes, total)
 		return total
 	}
 }
 
-func SolvePart2(nums []int) int {
+// Tries the changes of every buyer, checking ctx before each buyer.
+func SolvePart2(ctx context.Context, nums []int) (int, error) {
 	pricesList := make([][]int, len(nums))
 	for i, num := range nums {
 		pricesList[i], _ = FindFirstNPricesAndNthSecret(num, 2000)
 	}
 
 	maxPrice := 0
-	priceWithChangesFn := GetTotalOfPricesWithChangeFn(pricesList)
-	fmt.Println(len(nums))
+	cache := NewPriceCache()
+	defer func() { memo.Report(ctx, "changes", cache.Stats()) }()
+	priceWithChangesFn := GetTotalOfPricesWithChangeFn(pricesList, cache)
 	changes := [4]int{}
-	for i, prices := range pricesList {
+	for _, prices := range pricesList {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		for i := 0; i < len(prices)-4; i++ {
 			for j := range changes {
 				changes[j] = prices[i+j+1] - prices[i+j]
@@ -114,24 +124,41 @@ func SolvePart2(nums []int) int {
 				maxPrice = total
 			}
 		}
-
-		fmt.Printf("checked list %d, max price now is %d\n", i, maxPrice)
 	}
 
-	return maxPrice
+	return maxPrice, nil
 }
 
-func ReadInput(input string) []int {
-	numTexts := strings.Split(input, "\n")
+func ReadInput(input string) ([]int, error) {
+	numTexts := parse.Lines(input)
 	nums := make([]int, len(numTexts))
 	for i, numText := range numTexts {
-		fmt.Sscanf(numText, "%d", &nums[i])
+		num, err := parse.Int(numText, i+1, 1)
+		if err != nil {
+			return nil, err
+		}
+		nums[i] = num
 	}
-	return nums
+	return nums, nil
 }
 
-func main() {
-	nums := ReadInput(input)
-	fmt.Println("Part 1 solution:", SolvePart1(nums))
-	fmt.Println("Part 2 solution:", SolvePart2(nums))
+func init() {
+	registry.Register(registry.Day{
+		Number: 22,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			nums, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart1(nums), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			nums, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart2(ctx, nums)
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,12 +1,16 @@
-package main
+package day22
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
 )
 
 func TestGenerateNextPseudoRandomNumber(t *testing.T) {
-	nums := ReadInput(`15887950
+	nums, err := ReadInput(`15887950
 16495136
 527345
 704524
@@ -16,6 +20,9 @@ func TestGenerateNextPseudoRandomNumber(t *testing.T) {
 12249484
 7753432
 5908254`)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 
 	type testinfo struct {
 		Name  string
@@ -93,7 +100,10 @@ func TestFindNthSecretNumber(t *testing.T) {
 
 func TestPart1Solution(t *testing.T) {
 	input := "1\n10\n100\n2024"
-	nums := ReadInput(input)
+	nums, err := ReadInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	got := SolvePart1(nums)
 	want := 37327623
 
@@ -140,7 +150,7 @@ func TestTotalOfPricesWithChanges(t *testing.T) {
 		{"from the test input", [4]int{-2, 1, -1, 3}, 23},
 		{"from the test input", [4]int{-2, 1, -1, 3}, 23},
 	}
-	totalPriceFn := GetTotalOfPricesWithChangeFn(pricesList)
+	totalPriceFn := GetTotalOfPricesWithChangeFn(pricesList, NewPriceCache())
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
 			got := totalPriceFn(testcase.Changes)
@@ -153,11 +163,42 @@ func TestTotalOfPricesWithChanges(t *testing.T) {
 
 func TestPart2Solution(t *testing.T) {
 	input := "1\n2\n3\n2024"
-	nums := ReadInput(input)
-	got := SolvePart2(nums)
+	nums, err := ReadInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	got, err := SolvePart2(context.Background(), nums)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	want := 23
 
 	if got != want {
 		t.Errorf("Got wrong output: got %d, want %d", got, want)
 	}
 }
+
+func TestPart2StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := SolvePart2(ctx, []int{1, 2, 3, 2024})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Got wrong error: got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestReadInputErrors(t *testing.T) {
+	_, err := ReadInput("1\n10\n1OO\n2024")
+	if want := `line 3, column 1: invalid number "1OO"`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 22, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 22, 2)
+}
@@ -0,0 +1,45 @@
+package day23
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Generate returns the connections of a network of the given number of
+// computers, with two letter names, some of them starting with a t. Every
+// pair of computers is connected with the given probability, and a group of
+// cliqueSize computers are all connected to each other.
+func Generate(rng *rand.Rand, computers int, probability float64, cliqueSize int) string {
+	names := make([]string, 0, computers)
+	for len(names) < computers {
+		name := string([]byte{byte('a' + rng.IntN(26)), byte('a' + rng.IntN(26))})
+		if rng.IntN(8) == 0 {
+			name = "t" + name[1:]
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	clique := rng.Perm(computers)[:min(cliqueSize, computers)]
+	connections := make([]string, 0)
+	for i := range names {
+		for j := i + 1; j < len(names); j++ {
+			if rng.Float64() < probability || (slices.Contains(clique, i) && slices.Contains(clique, j)) {
+				connections = append(connections, fmt.Sprintf("%s-%s", names[i], names[j]))
+			}
+		}
+	}
+
+	rng.Shuffle(len(connections), func(i, j int) {
+		connections[i], connections[j] = connections[j], connections[i]
+	})
+	return strings.Join(connections, "\n") + "\n"
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 520, 13.0/520, 13)
+}
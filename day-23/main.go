@@ -1,14 +1,14 @@
-package main
+package day23
 
 import (
-	_ "embed"
-	"fmt"
+	"context"
 	"slices"
 	"strings"
-)
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
 type Edge struct {
 	V1, V2 string
@@ -127,12 +127,15 @@ func SolvePart2(graph Graph) string {
 	}
 }
 
-func ReadInput(input string) Graph {
-	lines := strings.Split(input, "\n")
+func ReadInput(input string) (Graph, error) {
+	lines := parse.Lines(input)
 	edges := make([]Edge, 0, len(lines))
 	vertices := make([]string, 0, len(lines)*2)
-	for _, line := range lines {
-		v1, v2, _ := strings.Cut(line, "-")
+	for i, line := range lines {
+		v1, v2, found := strings.Cut(line, "-")
+		if !found || v1 == "" || v2 == "" || v1 == v2 {
+			return Graph{}, parse.Errorf(i+1, 0, "%q is not a connection like kh-tc", line)
+		}
 		if !slices.Contains(vertices, string(v1)) {
 			vertices = append(vertices, string(v1))
 		}
@@ -160,26 +163,40 @@ func ReadInput(input string) Graph {
 		graph.EdgeIndices[i] = []int{v1Index, v2Index}
 	}
 
-	return graph
+	return graph, nil
 }
 
-func (graph Graph) Display() string {
-	text := ""
-	for _, row := range graph.Edges {
-		for _, val := range row {
-			if val {
-				text += "1"
-			} else {
-				text += " "
+// Returns the adjacency matrix of the graph, with '#' for the connected
+// computers and '.' for the others.
+func (graph Graph) Matrix() grid.Grid[rune] {
+	matrix := grid.New(len(graph.Vertices), len(graph.Vertices), '.')
+	for i, row := range graph.Edges {
+		for j, connected := range row {
+			if connected {
+				matrix.Set(grid.Position{X: j, Y: i}, '#')
 			}
 		}
-		text += "\n"
 	}
-	return text
+	return matrix
 }
 
-func main() {
-	graph := ReadInput(input)
-	fmt.Println("Part 1 Solution:", SolvePart1(graph))
-	fmt.Println("Part 2 Solution:", SolvePart2(graph))
+func init() {
+	registry.Register(registry.Day{
+		Number: 23,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			graph, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart1(graph), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			graph, err := ReadInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return SolvePart2(graph), nil
+		},
+		Generate: GenerateInput,
+	})
 }
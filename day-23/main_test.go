@@ -1,9 +1,15 @@
-package main
+package day23
 
 import (
 	"fmt"
+	"math/bits"
+	"math/rand/v2"
 	"slices"
+	"strings"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
 )
 
 var testInput = `kh-tc
@@ -100,7 +106,10 @@ func TestFindInterconnectedComputers(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			graph := ReadInput(testcase.Input)
+			graph, err := ReadInput(testcase.Input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
 			got := FindInterconnectedComputersOfSize3(graph)
 			if len(got) != len(testcase.Want) {
 				t.Errorf("Got wrong length of interconnected comps: got %v, want %v", got, testcase.Want)
@@ -112,7 +121,10 @@ func TestFindInterconnectedComputers(t *testing.T) {
 }
 
 func TestPart1Solution(t *testing.T) {
-	graph := ReadInput(testInput)
+	graph, err := ReadInput(testInput)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	got := SolvePart1(graph)
 	want := 7
 	if got != want {
@@ -121,8 +133,99 @@ func TestPart1Solution(t *testing.T) {
 }
 
 func TestPart2Solution(t *testing.T) {
-	graph := ReadInput(testInput)
+	graph, err := ReadInput(testInput)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 
 	got := SolvePart2(graph)
 	fmt.Println(got)
 }
+
+func TestMatrix(t *testing.T) {
+	graph, err := ReadInput("a-b\nb-c")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := ".#.\n#.#\n.#.\n"
+	if got := graph.Matrix().String(); got != want {
+		t.Errorf("Got wrong matrix:\n%swant:\n%s", got, want)
+	}
+}
+
+func TestReadInputErrors(t *testing.T) {
+	_, err := ReadInput("kh-tc\nqp-kh\nde cg")
+	if want := `line 3: "de cg" is not a connection like kh-tc`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+// Checks every triple of computers, and every set of computers, for the
+// largest group where all are connected to each other.
+func bruteForce(graph Graph) (triangles int, largest int) {
+	n := len(graph.Vertices)
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			for k := j + 1; k < n; k++ {
+				if graph.Edges[i][j] && graph.Edges[j][k] && graph.Edges[i][k] &&
+					(graph.Vertices[i][0] == 't' || graph.Vertices[j][0] == 't' || graph.Vertices[k][0] == 't') {
+					triangles++
+				}
+			}
+		}
+	}
+
+	for set := 1; set < 1<<n; set++ {
+		if bits.OnesCount(uint(set)) > largest && isClique(graph, set) {
+			largest = bits.OnesCount(uint(set))
+		}
+	}
+	return triangles, largest
+}
+
+func isClique(graph Graph, set int) bool {
+	for i := range graph.Vertices {
+		for j := i + 1; j < len(graph.Vertices); j++ {
+			if set&(1<<i) != 0 && set&(1<<j) != 0 && !graph.Edges[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 3+rng.IntN(10), 0.4, 3+rng.IntN(3)) }
+	proptest.Check(t, generate, func(input string) error {
+		graph, err := ReadInput(input)
+		if err != nil {
+			return err
+		}
+		triangles, largest := bruteForce(graph)
+
+		if got := SolvePart1(graph); got != triangles {
+			return fmt.Errorf("part 1: got %d, want %d", got, triangles)
+		}
+
+		// The largest group may not be unique, so check that the answer is
+		// one of them.
+		names := strings.Split(SolvePart2(graph), ",")
+		set := 0
+		for _, name := range names {
+			set |= 1 << slices.Index(graph.Vertices, name)
+		}
+		if !slices.IsSorted(names) || len(names) != largest || !isClique(graph, set) {
+			return fmt.Errorf("part 2: got %s, want a sorted group of %d connected computers", strings.Join(names, ","), largest)
+		}
+		return nil
+	})
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 23, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 23, 2)
+}
@@ -0,0 +1,59 @@
+package day3
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Noise found between the instructions of the corrupted memory, and
+// corrupted instructions, in which every N is replaced by a number.
+var (
+	noiseChars = []byte("!@$%^&*()[]{}<>,;:'+-/? whenrotsfuxy")
+	corrupted  = []string{
+		"mul(N*", "mul ( N , N )", "mul[N,N]", "mul(N,N!", "mul(N N)",
+		"mul(1000,N)", "mul(0N,N)", "don't", "do(", "mul(,N)", "why()", "select()", "from()",
+	}
+)
+
+// Generate returns corrupted memory of the given number of lines of about
+// lineLength characters. It has valid mul instructions, do() and don't(),
+// and corrupted instructions that must be ignored, like mul(4* or mul[3,7].
+func Generate(rng *rand.Rand, lines, lineLength int) string {
+	var builder strings.Builder
+	for range lines {
+		line := 0
+		for line < lineLength {
+			var text string
+			switch n := rng.IntN(20); {
+			case n < 6:
+				text = fmt.Sprintf("mul(%d,%d)", 1+rng.IntN(999), 1+rng.IntN(999))
+			case n == 6:
+				text = "do()"
+			case n == 7:
+				text = "don't()"
+			case n == 8:
+				text = corrupted[rng.IntN(len(corrupted))]
+				for strings.Contains(text, "N") {
+					text = strings.Replace(text, "N", strconv.Itoa(1+rng.IntN(999)), 1)
+				}
+			default:
+				noise := make([]byte, 1+rng.IntN(8))
+				for i := range noise {
+					noise[i] = noiseChars[rng.IntN(len(noiseChars))]
+				}
+				text = string(noise)
+			}
+			builder.WriteString(text)
+			line += len(text)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 6, 3200)
+}
@@ -0,0 +1,137 @@
+package day3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Machine is the state the instructions run on.
+type Machine struct {
+	Enabled bool
+	Total   int
+}
+
+// Instruction is an instruction of the corrupted memory, written as its name
+// followed by its arguments in parentheses, separated by commas, like
+// mul(2,4). No spaces are allowed, and the arguments are numbers without a
+// sign or leading zeros, so mul(0,4) is an instruction but mul(04,4) is not.
+type Instruction struct {
+	// Name of the instruction. It can hold anything but digits, commas and
+	// parentheses, so that an instruction never starts inside the arguments
+	// of another.
+	Name  string
+	Arity int
+	// Fewest and most digits of every argument. The fewest is 1 if not set,
+	// and the most must be from 1 to 18, so that the arguments never
+	// overflow.
+	MinDigits, MaxDigits int
+	Run                  func(m *Machine, args []int)
+
+	// The bytes starting the instruction: its name and the opening
+	// parenthesis, set when registered.
+	opening string
+}
+
+func (inst *Instruction) minDigits() int {
+	return max(inst.MinDigits, 1)
+}
+
+// Mul adds the product of its two arguments of 1 to 3 digits to the total,
+// while the machine is enabled.
+var Mul = Instruction{
+	Name:      "mul",
+	Arity:     2,
+	MaxDigits: 3,
+	Run: func(m *Machine, args []int) {
+		if m.Enabled {
+			m.Total += args[0] * args[1]
+		}
+	},
+}
+
+// Do enables the mul instructions after it.
+var Do = Instruction{
+	Name: "do",
+	Run:  func(m *Machine, args []int) { m.Enabled = true },
+}
+
+// Dont disables the mul instructions after it.
+var Dont = Instruction{
+	Name: "don't",
+	Run:  func(m *Machine, args []int) { m.Enabled = false },
+}
+
+// Interpreter runs the instructions registered with it, ignoring the rest of
+// the corrupted memory. It must not be changed once running.
+type Interpreter struct {
+	instructions []Instruction
+	// Indices of the instructions starting with each byte.
+	byFirstByte [256][]int
+}
+
+// NewInterpreter returns an interpreter of the given instructions, or the
+// error of the first one that cannot be registered.
+func NewInterpreter(instructions ...Instruction) (*Interpreter, error) {
+	in := &Interpreter{}
+	for _, inst := range instructions {
+		if err := in.Register(inst); err != nil {
+			return nil, err
+		}
+	}
+	return in, nil
+}
+
+// Register adds an instruction, which must have a name of its own.
+func (in *Interpreter) Register(inst Instruction) error {
+	if inst.Name == "" || strings.ContainsAny(inst.Name, "0123456789,()") {
+		return fmt.Errorf("invalid instruction name %q, want a name without digits, commas or parentheses", inst.Name)
+	}
+	for _, registered := range in.instructions {
+		if registered.Name == inst.Name {
+			return fmt.Errorf("instruction %q is already registered", inst.Name)
+		}
+	}
+	if inst.Arity < 0 {
+		return fmt.Errorf("instruction %q: invalid arity %d", inst.Name, inst.Arity)
+	}
+	if inst.Arity > 0 && (inst.MaxDigits < 1 || inst.MaxDigits > 18) {
+		return fmt.Errorf("instruction %q: invalid max digits %d, want 1 to 18", inst.Name, inst.MaxDigits)
+	}
+	if inst.Arity > 0 && inst.minDigits() > inst.MaxDigits {
+		return fmt.Errorf("instruction %q: min digits %d is more than max digits %d", inst.Name, inst.MinDigits, inst.MaxDigits)
+	}
+	if inst.Run == nil {
+		return fmt.Errorf("instruction %q: missing Run", inst.Name)
+	}
+
+	inst.opening = inst.Name + "("
+	in.instructions = append(in.instructions, inst)
+	first := inst.Name[0]
+	in.byFirstByte[first] = append(in.byFirstByte[first], len(in.instructions)-1)
+	return nil
+}
+
+// Lex returns a lexer of the instructions of the interpreter in the corrupted
+// memory read from r.
+func (in *Interpreter) Lex(r io.Reader) *Lexer {
+	return &Lexer{interpreter: in, r: bufio.NewReader(r)}
+}
+
+// Run runs every instruction of the corrupted memory read from r, on a
+// machine starting enabled, and returns the machine.
+func (in *Interpreter) Run(r io.Reader) (Machine, error) {
+	m := Machine{Enabled: true}
+	lexer := in.Lex(r)
+	for {
+		token, err := lexer.Next()
+		if err == io.EOF {
+			return m, nil
+		}
+		if err != nil {
+			return m, err
+		}
+		token.Instruction.Run(&m, token.Args)
+	}
+}
@@ -0,0 +1,156 @@
+package day3
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Token is an instruction found in the corrupted memory, with its arguments
+// and the offset of its first byte.
+type Token struct {
+	Instruction *Instruction
+	Args        []int
+	Offset      int64
+}
+
+func (t Token) String() string {
+	args := make([]string, len(t.Args))
+	for i, arg := range t.Args {
+		args[i] = strconv.Itoa(arg)
+	}
+	return fmt.Sprintf("%s(%s) at %d", t.Instruction.Name, strings.Join(args, ","), t.Offset)
+}
+
+// Lexer finds the instructions of an interpreter in the corrupted memory, in
+// a single pass over the bytes. Every byte that can start an instruction
+// starts a candidate, which follows the bytes after it until it completes or
+// stops matching. A candidate is never longer than its instruction can be, so
+// only a few are ever followed at once and the time is linear in the input.
+type Lexer struct {
+	interpreter *Interpreter
+	r           *bufio.Reader
+	offset      int64
+	candidates  []candidate
+	// Instructions completed, returned from next on.
+	pending []Token
+	next    int
+}
+
+// An instruction being matched, from the byte at start.
+type candidate struct {
+	instruction *Instruction
+	start       int64
+	// Bytes of the name and the opening parenthesis matched, while matching
+	// them.
+	matched int
+	args    []int
+	// The argument being read, and its number of digits.
+	value, digits int
+}
+
+// Next returns the next instruction, or io.EOF once there are none left.
+// Instructions sharing their last byte come in the order of their offsets.
+func (l *Lexer) Next() (Token, error) {
+	if l.next == len(l.pending) {
+		l.pending, l.next = l.pending[:0], 0
+	}
+	for len(l.pending) == 0 {
+		b, err := l.r.ReadByte()
+		if err != nil {
+			return Token{}, err
+		}
+		l.step(b)
+	}
+
+	token := l.pending[l.next]
+	l.next++
+	return token, nil
+}
+
+// Advances every candidate over a byte, keeping the ones still matching, and
+// starts new candidates from the byte.
+func (l *Lexer) step(b byte) {
+	starts := l.interpreter.byFirstByte[b]
+	if len(l.candidates) == 0 && len(starts) == 0 {
+		// Most of the memory is noise, matching nothing.
+		l.offset++
+		return
+	}
+
+	kept := 0
+	for i := range l.candidates {
+		c := &l.candidates[i]
+		done, ok := c.advance(b)
+		switch {
+		case done:
+			l.pending = append(l.pending, Token{Instruction: c.instruction, Args: c.args, Offset: c.start})
+		case ok:
+			if kept != i {
+				l.candidates[kept] = *c
+			}
+			kept++
+		}
+	}
+	clear(l.candidates[kept:])
+	l.candidates = l.candidates[:kept]
+
+	for _, i := range starts {
+		instruction := &l.interpreter.instructions[i]
+		l.candidates = append(l.candidates, candidate{instruction: instruction, start: l.offset, matched: 1})
+	}
+	l.offset++
+}
+
+// Advances a candidate over a byte, telling whether the instruction is
+// complete, and otherwise whether it still matches.
+func (c *candidate) advance(b byte) (done, ok bool) {
+	instruction := c.instruction
+	if c.matched < len(instruction.opening) {
+		if b != instruction.opening[c.matched] {
+			return false, false
+		}
+		c.matched++
+		if c.matched == len(instruction.opening) {
+			c.args = make([]int, 0, instruction.Arity)
+		}
+		return false, true
+	}
+
+	switch {
+	case b >= '0' && b <= '9':
+		if c.digits == instruction.MaxDigits || len(c.args) == instruction.Arity {
+			return false, false
+		}
+		if c.digits == 1 && c.value == 0 {
+			// A leading zero.
+			return false, false
+		}
+		c.value = c.value*10 + int(b-'0')
+		c.digits++
+		return false, true
+	case b == ',':
+		if c.digits < instruction.minDigits() || len(c.args)+1 >= instruction.Arity {
+			return false, false
+		}
+		c.pushArg()
+		return false, true
+	case b == ')':
+		if instruction.Arity == 0 {
+			return true, false
+		}
+		if c.digits < instruction.minDigits() || len(c.args)+1 != instruction.Arity {
+			return false, false
+		}
+		c.pushArg()
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+func (c *candidate) pushArg() {
+	c.args = append(c.args, c.value)
+	c.value, c.digits = 0, 0
+}
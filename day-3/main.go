@@ -1,62 +1,47 @@
-package main
+package day3
 
 import (
-	_ "embed"
-	"fmt"
+	"context"
 	"strings"
-)
-
-//go:embed input.txt
-var input string
 
-func getMulValueIfStartsWith(text string) int {
-	if !strings.HasPrefix(text, "mul(") {
-		return 0
-	}
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
-	var a, b int
-	n, err := fmt.Sscanf(text, "mul(%d,%d)", &a, &b)
-	if err != nil || n != 2 {
-		return 0
-	}
-	if a >= 1000 || b >= 1000 || a < 0 || b < 0 {
-		return 0
-	}
+var (
+	mulInterpreter      = mustInterpreter(Mul)
+	enablingInterpreter = mustInterpreter(Mul, Do, Dont)
+)
 
-	// For spaces in text. SScanf ignores the spaces
-	if !strings.HasPrefix(text, fmt.Sprintf("mul(%d,%d)", a, b)) {
-		return 0
+func mustInterpreter(instructions ...Instruction) *Interpreter {
+	in, err := NewInterpreter(instructions...)
+	if err != nil {
+		panic(err)
 	}
-	return a * b
-
+	return in
 }
 
+// Adds up the products of the mul instructions of the corrupted memory.
 func TotalMulValue(input string) int {
-	total := 0
-	for i := range input {
-		total += getMulValueIfStartsWith(input[i:])
-	}
-	return total
+	m, _ := mulInterpreter.Run(strings.NewReader(input))
+	return m.Total
 }
 
+// Adds up the products of the mul instructions of the corrupted memory that
+// are enabled, do() enabling those after it and don't() disabling them.
 func TotalMulValueWithEnabling(input string) int {
-	total := 0
-	enabled := true
-	for i := range input {
-		text := input[i:]
-		switch {
-		case strings.HasPrefix(text, "don't()"):
-			enabled = false
-		case strings.HasPrefix(text, "do()"):
-			enabled = true
-		case enabled:
-			total += getMulValueIfStartsWith(text)
-		}
-	}
-	return total
+	m, _ := enablingInterpreter.Run(strings.NewReader(input))
+	return m.Total
 }
 
-func main() {
-	fmt.Println("1st part result:", TotalMulValue(input))
-	fmt.Println("2nd part result:", TotalMulValueWithEnabling(input))
+func init() {
+	registry.Register(registry.Day{
+		Number: 3,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			return TotalMulValue(input), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			return TotalMulValueWithEnabling(input), nil
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,6 +1,16 @@
-package main
+package day3
 
-import "testing"
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
+)
 
 func TestTotalMulValue(t *testing.T) {
 	testcases := []struct {
@@ -18,6 +28,9 @@ func TestTotalMulValue(t *testing.T) {
 		{"value of mul(1,) is 0", "mul(1,)", 0},
 		{"value of mul(1024,98) is 0 (because larger than 3 digits)", "mul(1024,98)", 0},
 		{"value of mul(-1,2) is 0 (because negative)", "mul(-1,2)", 0},
+		{"value of mul(002,3) is 0 (because of leading zeros)", "mul(002,3)", 0},
+		{"value of mul(3,02) is 0 (because of a leading zero)", "mul(3,02)mul(0,2)", 0},
+		{"value of mul(10,30) is 300 (zeros not leading)", "mul(10,30)", 300},
 		{"value of mul(-1,2!) is 0", "mul(1,2!)", 0},
 		{"value of mul(1, 2) is 0 (space)", "mul(1, 2)", 0},
 		{"value of mul (1,2) is 0 (space)", "mul (1,2)", 0},
@@ -72,3 +85,183 @@ func TestTotalMulValueWithEnable(t *testing.T) {
 		})
 	}
 }
+
+func TestLexer(t *testing.T) {
+	input := "xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))"
+	lexer := enablingInterpreter.Lex(strings.NewReader(input))
+	got := make([]string, 0)
+	for {
+		token, err := lexer.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		got = append(got, token.String())
+	}
+
+	want := []string{
+		"mul(2,4) at 1",
+		"don't() at 20",
+		"mul(5,5) at 28",
+		"mul(11,8) at 48",
+		"do() at 59",
+		"mul(8,5) at 64",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Got wrong tokens: got %v, want %v", got, want)
+	}
+}
+
+func TestCustomInstructions(t *testing.T) {
+	add := Instruction{
+		Name:      "add",
+		Arity:     3,
+		MaxDigits: 5,
+		Run: func(m *Machine, args []int) {
+			m.Total += args[0] + args[1] + args[2]
+		},
+	}
+	double := Instruction{
+		Name:      "double",
+		Arity:     1,
+		MinDigits: 2,
+		MaxDigits: 2,
+		Run:       func(m *Machine, args []int) { m.Total *= 2 },
+	}
+	reset := Instruction{
+		Name: "reset",
+		Run:  func(m *Machine, args []int) { m.Total = 0 },
+	}
+	in, err := NewInterpreter(Mul, add, double, reset)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	testcases := []struct {
+		Name  string
+		Input string
+		Want  int
+	}{
+		{"three arguments", "add(1,20,300)", 321},
+		{"five digits", "add(12345,0,0)", 12345},
+		{"too many digits", "add(123456,0,0)", 0},
+		{"too few arguments", "add(1,2)", 0},
+		{"too many arguments", "add(1,2,3,4)", 0},
+		{"exact number of digits", "mul(2,3)double(10)", 12},
+		{"too few digits", "mul(2,3)double(1)", 6},
+		{"no arguments", "mul(2,3)reset()mul(1,1)", 1},
+		{"arguments of an instruction without any", "mul(2,3)reset(1)", 6},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			m, err := in.Run(strings.NewReader(testcase.Input))
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if m.Total != testcase.Want {
+				t.Errorf("Got wrong value: got %d want %d", m.Total, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	run := func(m *Machine, args []int) {}
+	testcases := []struct {
+		Name    string
+		Input   Instruction
+		WantErr string
+	}{
+		{"empty name", Instruction{Run: run}, `invalid instruction name "", want a name without digits, commas or parentheses`},
+		{"name with a parenthesis", Instruction{Name: "f(", Run: run}, `invalid instruction name "f(", want a name without digits, commas or parentheses`},
+		{"name with a digit", Instruction{Name: "mul2", Run: run}, `invalid instruction name "mul2", want a name without digits, commas or parentheses`},
+		{"name already registered", Instruction{Name: "mul", Run: run}, `instruction "mul" is already registered`},
+		{"negative arity", Instruction{Name: "f", Arity: -1, Run: run}, `instruction "f": invalid arity -1`},
+		{"no max digits", Instruction{Name: "f", Arity: 1, Run: run}, `instruction "f": invalid max digits 0, want 1 to 18`},
+		{"too many digits", Instruction{Name: "f", Arity: 1, MaxDigits: 19, Run: run}, `instruction "f": invalid max digits 19, want 1 to 18`},
+		{"min digits over max digits", Instruction{Name: "f", Arity: 1, MinDigits: 3, MaxDigits: 2, Run: run}, `instruction "f": min digits 3 is more than max digits 2`},
+		{"no run", Instruction{Name: "f"}, `instruction "f": missing Run`},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			in, err := NewInterpreter(Mul)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			err = in.Register(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+// Looks for an instruction at every offset of the input, parsing it with
+// Sscanf, as the first solution did.
+func bruteForce(input string) (any, any) {
+	mulValue := func(text string) int {
+		if !strings.HasPrefix(text, "mul(") {
+			return 0
+		}
+		var a, b int
+		n, err := fmt.Sscanf(text, "mul(%d,%d)", &a, &b)
+		if err != nil || n != 2 || a >= 1000 || b >= 1000 || a < 0 || b < 0 {
+			return 0
+		}
+		if !strings.HasPrefix(text, fmt.Sprintf("mul(%d,%d)", a, b)) {
+			return 0
+		}
+		return a * b
+	}
+
+	total, enabledTotal := 0, 0
+	enabled := true
+	for i := range input {
+		text := input[i:]
+		switch {
+		case strings.HasPrefix(text, "don't()"):
+			enabled = false
+		case strings.HasPrefix(text, "do()"):
+			enabled = true
+		default:
+			value := mulValue(text)
+			total += value
+			if enabled {
+				enabledTotal += value
+			}
+		}
+	}
+	return total, enabledTotal
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(5), 50+rng.IntN(200)) }
+	proptest.CheckParts(t, 3, generate, bruteForce)
+}
+
+func TestLongInput(t *testing.T) {
+	// Every byte starts or continues an instruction that never completes.
+	input := strings.Repeat("mul(123,mudon't(do(", 1<<18) + "mul(2,3)"
+	if got := TotalMulValueWithEnabling(input); got != 6 {
+		t.Errorf("Got wrong value: got %d want %d", got, 6)
+	}
+}
+
+func BenchmarkInterpreter(b *testing.B) {
+	input := Generate(rand.New(rand.NewPCG(1, 3)), 1000, 4000)
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		TotalMulValueWithEnabling(input)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 3, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 3, 2)
+}
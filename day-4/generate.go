@@ -0,0 +1,53 @@
+package day4
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Generate returns a square of random letters X, M, A and S of the given
+// size, with the given number of XMAS written over it in all eight
+// directions, and as many X-MAS, so that both parts find plenty of words.
+func Generate(rng *rand.Rand, size, words int) string {
+	letters := make([][]byte, size)
+	for i := range letters {
+		letters[i] = make([]byte, size)
+		for j := range letters[i] {
+			letters[i][j] = "XMAS"[rng.IntN(4)]
+		}
+	}
+
+	for range words {
+		dx, dy := rng.IntN(3)-1, rng.IntN(3)-1
+		if dx == 0 && dy == 0 {
+			dx = 1
+		}
+		x, y := 3+rng.IntN(size-6), 3+rng.IntN(size-6)
+		for k := range 4 {
+			letters[y+k*dy][x+k*dx] = "XMAS"[k]
+		}
+
+		// An X-MAS: the two MAS crossing at their A, each read either way.
+		x, y = 1+rng.IntN(size-2), 1+rng.IntN(size-2)
+		letters[y][x] = 'A'
+		for _, corners := range [][2][2]int{{{-1, -1}, {1, 1}}, {{1, -1}, {-1, 1}}} {
+			m, s := corners[0], corners[1]
+			if rng.IntN(2) == 0 {
+				m, s = s, m
+			}
+			letters[y+m[1]][x+m[0]] = 'M'
+			letters[y+s[1]][x+s[0]] = 'S'
+		}
+	}
+
+	lines := make([]string, size)
+	for i, line := range letters {
+		lines[i] = string(line)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 140, 1000)
+}
@@ -1,13 +1,11 @@
-package main
+package day4
 
 import (
-	_ "embed"
-	"fmt"
-	"strings"
-)
+	"context"
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
 type XmasCountRule func(i, j int) bool
 
@@ -42,10 +40,21 @@ func CheckStartsWithUsingDirection(lines []string, dx, dy int, word string) Xmas
 	}
 }
 
-func XmasCount(input string) int {
-	lines := strings.Split(input, "\n")
-	if len(lines) == 0 {
-		return 0
+// Read the lines of the word search. All the lines should have the same length.
+func ReadInput(input string) ([]string, error) {
+	lines := parse.Lines(input)
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			return nil, parse.Errorf(i+1, 0, "has %d letters, want %d like the first line", len(line), len(lines[0]))
+		}
+	}
+	return lines, nil
+}
+
+func XmasCount(input string) (int, error) {
+	lines, err := ReadInput(input)
+	if err != nil || len(lines) == 0 {
+		return 0, err
 	}
 
 	getDirectionRule := func(dx, dy int) XmasCountRule {
@@ -68,7 +77,7 @@ func XmasCount(input string) int {
 			}
 		}
 	}
-	return total
+	return total, nil
 }
 
 type MasCornerRules struct {
@@ -108,10 +117,10 @@ func IsXmasCross(lines []string, i, j int, rules MasCornerRules) bool {
 	return false
 }
 
-func Count_X_mas_Cross(input string) int {
-	lines := strings.Split(input, "\n")
-	if len(lines) == 0 {
-		return 0
+func Count_X_mas_Cross(input string) (int, error) {
+	lines, err := ReadInput(input)
+	if err != nil || len(lines) == 0 {
+		return 0, err
 	}
 
 	rules := GetMasDirectionRulesForLines(lines)
@@ -123,10 +132,18 @@ func Count_X_mas_Cross(input string) int {
 			}
 		}
 	}
-	return total
+	return total, nil
 }
 
-func main() {
-	fmt.Println("Solution to part 1:", XmasCount(input))
-	fmt.Println("Solution to part 2:", Count_X_mas_Cross(input))
+func init() {
+	registry.Register(registry.Day{
+		Number: 4,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			return XmasCount(input)
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			return Count_X_mas_Cross(input)
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,8 +1,10 @@
-package main
+package day4
 
 import (
 	"strings"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
 )
 
 func TestXmasCount(t *testing.T) {
@@ -90,7 +92,10 @@ MXMXAXMASX`,
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			got := XmasCount(testcase.Input)
+			got, err := XmasCount(testcase.Input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
 			if got != testcase.Want {
 				t.Errorf("got invalid output: got %d, want %d", got, testcase.Want)
 			}
@@ -154,8 +159,26 @@ SAXAMASAAA
 MAMMMXMMMM
 MXMXAXMASX`
 
-	got := Count_X_mas_Cross(input)
+	got, err := Count_X_mas_Cross(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	if got != 9 {
 		t.Errorf("got invalid output: got %d, want %d", got, 9)
 	}
 }
+
+func TestReadInputErrors(t *testing.T) {
+	_, err := XmasCount("XMAS\nXM\nSAMX")
+	if want := "line 2: has 2 letters, want 4 like the first line"; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 4, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 4, 2)
+}
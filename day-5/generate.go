@@ -0,0 +1,54 @@
+package day5
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Generate returns the rules of the given odd number of two digit pages, and
+// updates of at most pages/2 + 1 pages. As in the puzzle, there is a rule for
+// every pair of pages, but the rules cannot sort all the pages: the pages are
+// on a circle, each one coming before the pages/2 pages after it on the
+// circle. The pages of an update are taken from a half of the circle, so that
+// the rules always sort them. About half of the updates are in the right
+// order.
+func Generate(rng *rand.Rand, pages, updates int) string {
+	numbers := rng.Perm(90)[:pages]
+	half := pages / 2
+
+	rules := make([]string, 0, pages*half)
+	for i, before := range numbers {
+		for j := 1; j <= half; j++ {
+			after := numbers[(i+j)%pages]
+			rules = append(rules, fmt.Sprintf("%d|%d", 10+before, 10+after))
+		}
+	}
+	rng.Shuffle(len(rules), func(i, j int) { rules[i], rules[j] = rules[j], rules[i] })
+
+	var builder strings.Builder
+	builder.WriteString(strings.Join(rules, "\n") + "\n\n")
+	for range updates {
+		start := rng.IntN(pages)
+		// An odd number of pages from 5, so that the update has a middle page.
+		size := 5 + 2*rng.IntN((half-3)/2+1)
+		offsets := rng.Perm(half + 1)[:size]
+		if rng.IntN(2) == 0 {
+			slices.Sort(offsets)
+		}
+
+		texts := make([]string, size)
+		for i, offset := range offsets {
+			texts[i] = strconv.Itoa(10 + numbers[(start+offset)%pages])
+		}
+		builder.WriteString(strings.Join(texts, ",") + "\n")
+	}
+	return builder.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 49, 200)
+}
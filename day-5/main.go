@@ -1,15 +1,14 @@
-package main
+package day5
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
 	"slices"
-	"strconv"
 	"strings"
-)
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
 type PageRule struct {
 	Before string
@@ -24,9 +23,37 @@ func (rule PageRule) IsRuleFollowed(pages []string) (bool, int, int) {
 	return isFollowed, beforeIndex, afterIndex
 }
 
-func getPageRule(ruleText string) PageRule {
-	before, after, _ := strings.Cut(ruleText, "|")
-	return PageRule{Before: before, After: after}
+func getPageRule(ruleText string, lineNumber int) (PageRule, error) {
+	before, after, found := strings.Cut(ruleText, "|")
+	if !found || before == "" || after == "" {
+		return PageRule{}, parse.Errorf(lineNumber, 0, "%q is not a rule like 47|53", ruleText)
+	}
+	return PageRule{Before: before, After: after}, nil
+}
+
+// Read the rules, one per line. [firstLine] is the line of the input where
+// the rules start.
+func ReadRules(ruleSection string, firstLine int) ([]PageRule, error) {
+	lines := parse.Lines(ruleSection)
+	rules := make([]PageRule, len(lines))
+	for i, line := range lines {
+		rule, err := getPageRule(line, firstLine+i)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// Find the value of the page in the middle of the update.
+func getCenterPage(pages []string, lineNumber int) (int, error) {
+	center := len(pages) / 2
+	column := 1
+	for _, page := range pages[:center] {
+		column += len(page) + 1
+	}
+	return parse.Int(pages[center], lineNumber, column)
 }
 
 func IsUpdateInRightOrder(rules []PageRule, pages []string) bool {
@@ -38,40 +65,38 @@ func IsUpdateInRightOrder(rules []PageRule, pages []string) bool {
 	return true
 }
 
-func MapLines[T any](text string, mapper func(string) T) []T {
-	lines := strings.Split(text, "\n")
-	values := make([]T, len(lines))
-	for i, line := range lines {
-		values[i] = mapper(line)
+func FindSumOfMedians(input string) (int, int, error) {
+	blocks := parse.Blocks(input)
+	if len(blocks) != 2 {
+		return 0, 0, fmt.Errorf("want rules and updates separated by a blank line, got %d sections", len(blocks))
 	}
-	return values
-}
 
-func FindSumOfMedians(input string) (int, int) {
-	ruleSection, updateSection, _ := strings.Cut(input, "\n\n")
-	rules := MapLines(ruleSection, getPageRule)
-	updates := MapLines(updateSection, func(s string) []string {
-		return strings.Split(s, ",")
-	})
+	rules, err := ReadRules(blocks[0].Text, blocks[0].Line)
+	if err != nil {
+		return 0, 0, err
+	}
 
 	totalOfCorrect := 0
 	totalOfReordered := 0
-	for _, pages := range updates {
+	for i, line := range parse.Lines(blocks[1].Text) {
+		pages := strings.Split(line, ",")
 		isCorrect := IsUpdateInRightOrder(rules, pages)
 		if !isCorrect {
 			ReorderUpdates(pages, rules)
 		}
-		center := len(pages) / 2
-		value, _ := strconv.ParseInt(pages[center], 10, 32)
+		value, err := getCenterPage(pages, blocks[1].Line+i)
+		if err != nil {
+			return 0, 0, err
+		}
 
 		if isCorrect {
-			totalOfCorrect += int(value)
+			totalOfCorrect += value
 		} else {
-			totalOfReordered += int(value)
+			totalOfReordered += value
 		}
 	}
 
-	return totalOfCorrect, totalOfReordered
+	return totalOfCorrect, totalOfReordered, nil
 }
 
 func ReorderUpdates(pages []string, rules []PageRule) {
@@ -87,8 +112,17 @@ func ReorderUpdates(pages []string, rules []PageRule) {
 
 }
 
-func main() {
-	part1Sol, part2Sol := FindSumOfMedians(input)
-	fmt.Println("Solution to part 1:", part1Sol)
-	fmt.Println("Solution to part 2:", part2Sol)
+func init() {
+	registry.Register(registry.Day{
+		Number: 5,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			part1Sol, _, err := FindSumOfMedians(input)
+			return part1Sol, err
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			_, part2Sol, err := FindSumOfMedians(input)
+			return part2Sol, err
+		},
+		Generate: GenerateInput,
+	})
 }
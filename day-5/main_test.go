@@ -1,9 +1,11 @@
-package main
+package day5
 
 import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
 )
 
 var exampleText = `47|53
@@ -37,7 +39,10 @@ var exampleText = `47|53
 
 func TestIsUpdateInRightOrder(t *testing.T) {
 	ruleSection, _, _ := strings.Cut(exampleText, "\n\n")
-	exampleRules := MapLines(ruleSection, getPageRule)
+	exampleRules, err := ReadRules(ruleSection, 1)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 
 	testcases := []struct {
 		Name    string
@@ -178,7 +183,10 @@ func TestFindSumOfMedianOfCorrectRecords(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			got1, got2 := FindSumOfMedians(testcase.Input)
+			got1, got2, err := FindSumOfMedians(testcase.Input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
 			if got1 != testcase.Want1 {
 				t.Errorf("Got wrong output: got %d, want %d", got1, testcase.Want1)
 			}
@@ -191,7 +199,10 @@ func TestFindSumOfMedianOfCorrectRecords(t *testing.T) {
 
 func TestReorderUpdates(t *testing.T) {
 	ruleSection, _, _ := strings.Cut(exampleText, "\n\n")
-	exampleRules := MapLines(ruleSection, getPageRule)
+	exampleRules, err := ReadRules(ruleSection, 1)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 
 	testcases := []struct {
 		Name    string
@@ -240,3 +251,32 @@ func TestReorderUpdates(t *testing.T) {
 		})
 	}
 }
+
+func TestFindSumOfMediansErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"rule without separator", "1|2\n3-4\n\n1,2,3", `line 2: "3-4" is not a rule like 47|53`},
+		{"middle page is not a number", "1|2\n\n1,2,3\n4,x,6", `line 4, column 3: invalid number "x"`},
+		{"updates are missing", "1|2\n3|4\n", "want rules and updates separated by a blank line, got 1 sections"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, _, err := FindSumOfMedians(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 5, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 5, 2)
+}
@@ -0,0 +1,152 @@
+package day6
+
+import (
+	"math/rand/v2"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+)
+
+// Generate returns a map of the given size, with obstacles on about the
+// given fraction of the cells. As in the puzzle, the guard always leaves the
+// map, so maps where the guard would walk in a loop are thrown away.
+func Generate(rng *rand.Rand, width, height int, density float64) string {
+	for {
+		cells := grid.New(width, height, '.')
+		for pos := range cells.Positions() {
+			if rng.Float64() < density {
+				cells.Set(pos, '#')
+			}
+		}
+		start := Position{X: rng.IntN(width), Y: rng.IntN(height)}
+		cells.Set(start, '^')
+
+		if _, leaves := walk(cells, start); leaves {
+			return cells.String()
+		}
+	}
+}
+
+// Walks the guard one cell at a time, and returns the number of distinct
+// cells walked, and whether the guard leaves the map instead of walking in a
+// loop.
+func walk(cells grid.Grid[rune], start Position) (int, bool) {
+	type state struct {
+		Pos Position
+		Dir Direction
+	}
+
+	seen := map[state]bool{}
+	walked := map[Position]bool{}
+	for guard := (state{start, UP}); !seen[guard]; {
+		seen[guard] = true
+		walked[guard.Pos] = true
+
+		next := guard.Pos.MoveAlong(guard.Dir)
+		switch {
+		case !cells.IsInBounds(next):
+			return len(walked), true
+		case cells.At(next) == '#':
+			guard.Dir = guard.Dir.Rotate90()
+		default:
+			guard.Pos = next
+		}
+	}
+	return len(walked), false
+}
+
+// GenerateLong returns a map like Generate, in which the guard walks at least
+// minWalked distinct cells before leaving, as in the real inputs. Random maps
+// rarely have such long walks, so the walk is drawn first: the guard goes
+// straight to a random cell it has never walked before, and an obstacle is put
+// on that cell. Once it has walked far enough, it goes on until it leaves. The
+// other obstacles are then put where the guard never walks.
+func GenerateLong(rng *rand.Rand, width, height int, density float64, minWalked int) string {
+	for {
+		cells, start, ok := drawLongWalk(rng, width, height, minWalked)
+		if !ok {
+			continue
+		}
+		for cell, char := range cells.All() {
+			switch {
+			case char == 'X':
+				cells.Set(cell, '.')
+			case char == '.' && rng.Float64() < density:
+				cells.Set(cell, '#')
+			}
+		}
+		cells.Set(start, '^')
+		return cells.String()
+	}
+}
+
+// Draws the obstacles turning the guard, the cells walked being marked 'X',
+// and returns the start of the guard. It fails if the guard walks in a loop,
+// or leaves too early.
+func drawLongWalk(rng *rand.Rand, width, height, minWalked int) (grid.Grid[rune], Position, bool) {
+	type state struct {
+		Pos Position
+		Dir Direction
+	}
+
+	cells := grid.New(width, height, '.')
+	start := Position{X: width/4 + rng.IntN(width/2), Y: height/4 + rng.IntN(height/2)}
+	pos, dir, walked := start, UP, 1
+	cells.Set(start, 'X')
+	seen := map[state]bool{}
+	for !seen[state{pos, dir}] {
+		seen[state{pos, dir}] = true
+
+		ray := make([]Position, 0)
+		for next := pos.MoveAlong(dir); cells.IsInBounds(next) && cells.At(next) != '#'; next = next.MoveAlong(dir) {
+			ray = append(ray, next)
+		}
+
+		// An obstacle on a walked cell would change the walk before. The
+		// guard only stops where it can walk new cells after turning, as it
+		// would be bound to walk in a loop otherwise.
+		stops := make([]int, 0)
+		for i := 0; i < len(ray) && walked < minWalked; i++ {
+			from := pos
+			if i > 0 {
+				from = ray[i-1]
+			}
+			if cells.At(ray[i]) == '.' && hasNewCells(cells, from, dir.Rotate90()) {
+				stops = append(stops, i)
+			}
+		}
+		if len(stops) > 0 {
+			stop := stops[rng.IntN(len(stops))]
+			cells.Set(ray[stop], '#')
+			ray = ray[:stop]
+		}
+
+		for _, cell := range ray {
+			if cells.At(cell) == '.' {
+				cells.Set(cell, 'X')
+				walked++
+			}
+			pos = cell
+		}
+		if !cells.IsInBounds(pos.MoveAlong(dir)) {
+			return cells, start, walked >= minWalked
+		}
+		dir = dir.Rotate90()
+	}
+	return cells, start, false
+}
+
+// Reports whether the guard going straight from a position finds cells it
+// has never walked, marked '.', before an obstacle.
+func hasNewCells(cells grid.Grid[rune], pos Position, dir Direction) bool {
+	for next := pos.MoveAlong(dir); cells.IsInBounds(next) && cells.At(next) != '#'; next = next.MoveAlong(dir) {
+		if cells.At(next) == '.' {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return GenerateLong(rng, 130, 130, 0.04, 4500)
+}
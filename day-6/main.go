@@ -1,78 +1,70 @@
-package main
+package day6
 
 import (
-	_ "embed"
-	"fmt"
+	"context"
+	"errors"
 	"slices"
-	"strings"
-)
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
 
-type Direction struct {
-	X, Y int
-}
+type (
+	Direction = grid.Direction
+	Position  = grid.Position
+	Size      = grid.Size
+)
 
 var (
-	UP    = Direction{0, -1}
-	DOWN  = Direction{0, 1}
-	RIGHT = Direction{1, 0}
-	LEFT  = Direction{-1, 0}
+	UP    = grid.UP
+	DOWN  = grid.DOWN
+	RIGHT = grid.RIGHT
+	LEFT  = grid.LEFT
 )
 
-func (dir Direction) Rotate90() Direction {
-	switch dir {
-	case UP:
-		return RIGHT
-	case RIGHT:
-		return DOWN
-	case DOWN:
-		return LEFT
-	case LEFT:
-		return UP
-	}
-	panic("This is not supposed to happen")
-}
-
-type Position struct {
-	X, Y int
-}
-
 func NullPosition() Position {
-	return Position{-1, -1}
-}
-
-func (pos Position) IsNull() bool {
-	return pos.X == -1 && pos.Y == -1
+	return Position{X: -1, Y: -1}
 }
 
 type Guard struct {
 	Pos  Position
 	Dir  Direction
 	Path []Position
-}
 
-type Size struct {
-	Width, Height int
+	// Told about every cell the guard walks and every turn, if set.
+	Observer trace.Observer[GuardState]
+	steps    int
 }
 
-type Obstacles struct {
-	Locations []Position
+// The state of the guard after a step, as given to the observer.
+type GuardState struct {
+	Pos Position
+	Dir Direction
 }
 
-func (size Size) IsInBounds(pos Position) bool {
-	if pos.X < 0 || pos.Y < 0 || pos.X >= size.Width || pos.Y >= size.Height {
-		return false
+func (guard *Guard) observe(action string) {
+	if guard.Observer == nil {
+		return
 	}
-	return true
+	guard.Observer.Observe(trace.Step[GuardState]{
+		Index:  guard.steps,
+		Action: action,
+		State:  GuardState{guard.Pos, guard.Dir},
+	})
+	guard.steps++
+}
+
+type Obstacles struct {
+	Locations []Position
 }
 
 func (guard *Guard) IsInTheWay(obstacle Position) bool {
-	if guard.Dir.X == 0 {
-		return guard.Pos.X == obstacle.X && (guard.Pos.Y-obstacle.Y)*guard.Dir.Y < 0
+	if guard.Dir.Dx == 0 {
+		return guard.Pos.X == obstacle.X && (guard.Pos.Y-obstacle.Y)*guard.Dir.Dy < 0
 	}
-	return guard.Pos.Y == obstacle.Y && (guard.Pos.X-obstacle.X)*guard.Dir.X < 0
+	return guard.Pos.Y == obstacle.Y && (guard.Pos.X-obstacle.X)*guard.Dir.Dx < 0
 }
 
 // Excluding current guard pos
@@ -95,7 +87,7 @@ func (guard *Guard) FindNextObstacle(obs Obstacles) *Position {
 	minDistance := 100000000
 	for _, obstacle := range obs.Locations {
 		if guard.IsInTheWay(obstacle) {
-			distance := AbsDiff(guard.Pos.X, obstacle.X) + AbsDiff(guard.Pos.Y, obstacle.Y)
+			distance := grid.ManhattanDistance(guard.Pos, obstacle)
 			if distance < minDistance {
 				nearestObstacle = &obstacle
 				minDistance = distance
@@ -105,25 +97,18 @@ func (guard *Guard) FindNextObstacle(obs Obstacles) *Position {
 	return nearestObstacle
 }
 
-func AbsDiff(x, y int) int {
-	if x > y {
-		return x - y
-	}
-	return y - x
-}
-
 // Get the distance to next position, and whether it is an obstacle
 func (guard *Guard) FindDistanceToNextPos(obs Obstacles, size Size) (int, bool) {
 	nextObstacle := guard.FindNextObstacle(obs)
 	if nextObstacle != nil {
-		return AbsDiff(nextObstacle.X, guard.Pos.X) + AbsDiff(nextObstacle.Y, guard.Pos.Y) - 1, true
+		return grid.ManhattanDistance(*nextObstacle, guard.Pos) - 1, true
 	}
 
 	return guard.FindDistanceToEdge(size), false
 }
 
 func (guard *Guard) NextPosAfter1Time() Position {
-	return Position{guard.Pos.X + guard.Dir.X, guard.Pos.Y + guard.Dir.Y}
+	return guard.Pos.MoveAlong(guard.Dir)
 }
 
 func (guard *Guard) MoveToNextPos(obs Obstacles, size Size) bool {
@@ -132,53 +117,107 @@ func (guard *Guard) MoveToNextPos(obs Obstacles, size Size) bool {
 	for size.IsInBounds(nextPos) && (nextObstacle == nil || nextPos != *nextObstacle) {
 		guard.Path = append(guard.Path, nextPos)
 		guard.Pos = nextPos
+		guard.observe("forward")
 		nextPos = guard.NextPosAfter1Time()
 	}
+	if !size.IsInBounds(nextPos) {
+		guard.observe("leave")
+		return false
+	}
 	guard.Dir = guard.Dir.Rotate90()
-	return size.IsInBounds(nextPos)
+	guard.observe("turn")
+	return true
 }
 
 func (guard *Guard) CountPositions() int {
 	positions := make([]Position, 0)
 	total := 0
-	total2 := 0
 	for _, pos := range guard.Path {
 		if !slices.Contains(positions, pos) {
 			total += 1
 			positions = append(positions, pos)
-		} else {
-			total2 += 1
 		}
 	}
-	fmt.Println(total2)
 	return total
 }
 
-func GetInputGrid(input string) (Obstacles, Guard, Size) {
-	rows := strings.Split(input, "\n")
+func GetInputGrid(input string) (Obstacles, Guard, Size, error) {
+	cells, err := grid.Parse(input, grid.RunesIn(".#^"))
+	if err != nil {
+		return Obstacles{}, Guard{}, Size{}, err
+	}
+
 	obs := Obstacles{Locations: make([]Position, 0)}
-	guard := Guard{NullPosition(), UP, []Position{}}
-	for i, row := range rows {
-		for j, rune := range row {
-			switch rune {
-			case '#':
-				obs.Locations = append(obs.Locations, Position{j, i})
-			case '^':
-				guard.Pos = Position{j, i}
-				guard.Path = append(guard.Path, guard.Pos)
-			}
+	guard := Guard{Pos: NullPosition(), Dir: UP, Path: []Position{}}
+	for pos, char := range cells.All() {
+		switch char {
+		case '#':
+			obs.Locations = append(obs.Locations, pos)
+		case '^':
+			guard.Pos = pos
+			guard.Path = append(guard.Path, guard.Pos)
 		}
 	}
-	return obs, guard, Size{Height: len(rows), Width: len(rows[0])}
+
+	if guard.Pos == NullPosition() {
+		return Obstacles{}, Guard{}, Size{}, errors.New("the map has no guard, marked by '^'")
+	}
+	return obs, guard, cells.Size, nil
 }
 
-func FindGaurdPathLength(obs Obstacles, guard Guard, size Size) int {
+// Walk moves the guard until it leaves the map, or until ctx is cancelled.
+func (guard *Guard) Walk(ctx context.Context, obs Obstacles, size Size) error {
 	for guard.MoveToNextPos(obs, size) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+func FindGaurdPathLength(obs Obstacles, guard Guard, size Size) int {
+	guard.Walk(context.Background(), obs, size)
 	return guard.CountPositions()
 }
 
-func main() {
-	obs, guard, size := GetInputGrid(input)
-	fmt.Println(FindGaurdPathLength(obs, guard, size))
+var guardCells = map[Direction]rune{UP: '^', RIGHT: '>', DOWN: 'v', LEFT: '<'}
+
+// Draws the map after every step of the guard, with the cells already
+// walked marked by 'X'.
+func Animate(ctx context.Context, input string, observer trace.Observer[grid.Grid[rune]]) error {
+	obs, guard, size, err := GetInputGrid(input)
+	if err != nil {
+		return err
+	}
+
+	frame := grid.New(size.Width, size.Height, '.')
+	for _, obstacle := range obs.Locations {
+		frame.Set(obstacle, '#')
+	}
+	frame.Set(guard.Pos, 'X')
+	guard.Observer = trace.ObserverFunc[GuardState](func(step trace.Step[GuardState]) {
+		frame.Set(step.State.Pos, guardCells[step.State.Dir])
+		observer.Observe(trace.Step[grid.Grid[rune]]{Index: step.Index, Action: step.Action, State: frame.Copy()})
+		frame.Set(step.State.Pos, 'X')
+	})
+
+	return guard.Walk(ctx, obs, size)
+}
+
+func init() {
+	registry.Register(registry.Day{
+		Number: 6,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			obs, guard, size, err := GetInputGrid(input)
+			if err != nil {
+				return nil, err
+			}
+			if err := guard.Walk(ctx, obs, size); err != nil {
+				return nil, err
+			}
+			return guard.CountPositions(), nil
+		},
+		Generate: GenerateInput,
+	})
+	animate.Register(6, Animate)
 }
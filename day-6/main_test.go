@@ -1,7 +1,15 @@
-package main
+package day6
 
 import (
+	"context"
+	"math/rand/v2"
+	"reflect"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
 )
 
 func Test(t *testing.T) {
@@ -91,7 +99,10 @@ func Test(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			obs, guard, size := GetInputGrid(testcase.Input)
+			obs, guard, size, err := GetInputGrid(testcase.Input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
 			got := FindGaurdPathLength(obs, guard, size)
 			if got != testcase.Want {
 				t.Errorf("Wrong output returned: got %d, want %d", got, testcase.Want)
@@ -99,3 +110,93 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+func TestObserveGuardSteps(t *testing.T) {
+	obs, guard, size, err := GetInputGrid(".#.\n...\n.^.")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	recorder := &trace.Recorder[GuardState]{}
+	guard.Observer = recorder
+
+	FindGaurdPathLength(obs, guard, size)
+
+	wantActions := []string{"forward", "turn", "forward", "leave"}
+	if got := recorder.Actions(); !reflect.DeepEqual(got, wantActions) {
+		t.Errorf("Got wrong actions: got %v, want %v", got, wantActions)
+	}
+	last := recorder.Steps[len(recorder.Steps)-1]
+	if want := (GuardState{Position{X: 2, Y: 1}, RIGHT}); last.Index != 3 || last.State != want {
+		t.Errorf("Got wrong last step: got %+v, want index 3 and state %+v", last, want)
+	}
+}
+
+func TestGetInputGridErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"unknown cell", "..#\n.^.\n.O.", `line 3, column 2: unexpected 'O', want one of ".#^"`},
+		{"lines of different length", "..#\n.^\n...", "line 2: has 2 cells, want 3 like the first line"},
+		{"no guard", "..#\n...", "the map has no guard, marked by '^'"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, _, _, err := GetInputGrid(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestAnimate(t *testing.T) {
+	recorder := &trace.Recorder[grid.Grid[rune]]{}
+	if err := Animate(context.Background(), ".#.\n...\n.^.", recorder); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := []string{".#.\n.^.\n.X.\n", ".#.\n.>.\n.X.\n", ".#.\n.X>\n.X.\n", ".#.\n.X>\n.X.\n"}
+	if len(recorder.Steps) != len(want) {
+		t.Fatalf("Got wrong number of frames: got %d, want %d", len(recorder.Steps), len(want))
+	}
+	for i, step := range recorder.Steps {
+		if got := step.State.String(); got != want[i] {
+			t.Errorf("Got wrong frame %d:\n%swant:\n%s", i, got, want[i])
+		}
+	}
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(15), 1+rng.IntN(15), 0.15) }
+	proptest.CheckParts(t, 6, generate, func(input string) (any, any) {
+		cells, err := grid.ParseRunes(input)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		walked, _ := walk(cells, cells.FindAll(func(char rune) bool { return char == '^' })[0])
+		return walked, nil
+	})
+}
+
+func TestGenerateLong(t *testing.T) {
+	input := GenerateLong(proptest.NewRand(1), 60, 50, 0.05, 1000)
+	cells, err := grid.ParseRunes(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if cells.Width != 60 || cells.Height != 50 {
+		t.Errorf("Got wrong size: %dx%d", cells.Width, cells.Height)
+	}
+
+	walked, leaves := walk(cells, cells.FindAll(func(char rune) bool { return char == '^' })[0])
+	if !leaves || walked < 1000 {
+		t.Errorf("Got wrong walk: walked %d cells, leaving %v", walked, leaves)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 6, 1)
+}
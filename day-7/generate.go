@@ -0,0 +1,69 @@
+package day7
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Generate returns equations of 2 to maxNumbers numbers below maxNumber. Half
+// of them can be solved with the three operations, their total being computed
+// with random operations, while the others have a random total. As in the
+// puzzle, the totals are below 10^15, so numbers that would make a larger
+// total are drawn again.
+func Generate(rng *rand.Rand, equations, maxNumbers, maxNumber int) string {
+	var builder strings.Builder
+	for range equations {
+		nums, total := generateEquation(rng, maxNumbers, maxNumber)
+		for total >= maxTotal {
+			nums, total = generateEquation(rng, maxNumbers, maxNumber)
+		}
+		if rng.IntN(2) == 0 {
+			total = 1 + rng.Int64N(total)
+		}
+
+		numTexts := make([]string, len(nums))
+		for i, num := range nums {
+			numTexts[i] = strconv.FormatInt(num, 10)
+		}
+		fmt.Fprintf(&builder, "%d: %s\n", total, strings.Join(numTexts, " "))
+	}
+	return builder.String()
+}
+
+const maxTotal = 1_000_000_000_000_000
+
+// Returns the numbers of an equation and a total computed with random
+// operations, or maxTotal once the total gets too large.
+func generateEquation(rng *rand.Rand, maxNumbers, maxNumber int) ([]int64, int64) {
+	nums := make([]int64, 2+rng.IntN(maxNumbers-1))
+	for i := range nums {
+		nums[i] = 1 + rng.Int64N(int64(maxNumber-1))
+	}
+
+	total := nums[0]
+	for _, num := range nums[1:] {
+		switch rng.IntN(3) {
+		case 0:
+			total += num
+		case 1:
+			if total > maxTotal/num {
+				return nums, maxTotal
+			}
+			total *= num
+		default:
+			total, _ = strconv.ParseInt(fmt.Sprintf("%d%d", total, num), 10, 64)
+		}
+		// A concatenation too large to parse gives the largest int64.
+		if total >= maxTotal {
+			return nums, maxTotal
+		}
+	}
+	return nums, total
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 850, 12, 1000)
+}
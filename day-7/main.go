@@ -1,16 +1,14 @@
-package main
+package day7
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
-	"log"
 	"math"
-	"strconv"
 	"strings"
-)
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
 type Operation interface {
 	Reverse(total, num int64) (bool, int64)
@@ -90,35 +88,50 @@ type Equation struct {
 	Numbers []int64
 }
 
-func ParseEquationText(line string) Equation {
-	totalText, numsText, _ := strings.Cut(line, ": ")
-	total, err := strconv.ParseInt(totalText, 10, 64)
+func ParseEquationText(line string, lineNumber int) (Equation, error) {
+	totalText, numsText, found := strings.Cut(line, ": ")
+	if !found {
+		return Equation{}, parse.Errorf(lineNumber, 0, "%q is not an equation like 190: 10 19", line)
+	}
+
+	total, err := parse.Int(totalText, lineNumber, 1)
 	if err != nil {
-		log.Fatalln("Not able to parse total:", err)
+		return Equation{}, err
+	}
+
+	fields := parse.Fields(numsText)
+	if len(fields) == 0 {
+		return Equation{}, parse.Errorf(lineNumber, 0, "equation has no numbers")
 	}
 
-	numsTextSplit := strings.Split(numsText, " ")
-	nums := make([]int64, len(numsTextSplit))
-	for i, numText := range numsTextSplit {
-		num, err := strconv.ParseInt(numText, 10, 64)
+	nums := make([]int64, len(fields))
+	for i, field := range fields {
+		column := len(totalText) + 2 + field.Column
+		num, err := parse.Int(field.Text, lineNumber, column)
 		if err != nil {
-			log.Fatalln("Not able to parse total:", err)
+			return Equation{}, err
 		}
-		nums[i] = num
+		// The operations cannot be reversed for 0
+		if num <= 0 {
+			return Equation{}, parse.Errorf(lineNumber, column, "number %d is not positive", num)
+		}
+		nums[i] = int64(num)
 	}
 
-	return Equation{total, nums}
-
+	return Equation{int64(total), nums}, nil
 }
 
-func ParseEquations(input string) []Equation {
-
-	lines := strings.Split(input, "\n")
+func ParseEquations(input string) ([]Equation, error) {
+	lines := parse.Lines(input)
 	eqs := make([]Equation, len(lines))
 	for i, line := range lines {
-		eqs[i] = ParseEquationText(line)
+		eq, err := ParseEquationText(line, i+1)
+		if err != nil {
+			return nil, err
+		}
+		eqs[i] = eq
 	}
-	return eqs
+	return eqs, nil
 }
 
 func FindTotalOfValidEquations(eqs []Equation, ops []Operation) int64 {
@@ -132,8 +145,23 @@ func FindTotalOfValidEquations(eqs []Equation, ops []Operation) int64 {
 	return result
 }
 
-func main() {
-	eqs := ParseEquations(input)
-	fmt.Println("Solution to 1st part:", FindTotalOfValidEquations(eqs, []Operation{AddOp{}, MulOp{}}))
-	fmt.Println("Solution to 2nd part:", FindTotalOfValidEquations(eqs, []Operation{AddOp{}, MulOp{}, ConcatOp{}}))
+func init() {
+	registry.Register(registry.Day{
+		Number: 7,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			eqs, err := ParseEquations(input)
+			if err != nil {
+				return nil, err
+			}
+			return FindTotalOfValidEquations(eqs, []Operation{AddOp{}, MulOp{}}), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			eqs, err := ParseEquations(input)
+			if err != nil {
+				return nil, err
+			}
+			return FindTotalOfValidEquations(eqs, []Operation{AddOp{}, MulOp{}, ConcatOp{}}), nil
+		},
+		Generate: GenerateInput,
+	})
 }
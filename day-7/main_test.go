@@ -1,7 +1,13 @@
-package main
+package day7
 
 import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
 )
 
 func TestIsTotalPossible(t *testing.T) {
@@ -119,7 +125,10 @@ func TestFindTotalOfValidEquations(t *testing.T) {
 21037: 9 7 18 13
 292: 11 6 16 20`
 	want := int64(3749)
-	eqs := ParseEquations(input)
+	eqs, err := ParseEquations(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	got := FindTotalOfValidEquations(eqs, []Operation{AddOp{}, MulOp{}})
 
 	if got != want {
@@ -138,7 +147,10 @@ func TestFindTotalOfValidEquationsPart2(t *testing.T) {
 21037: 9 7 18 13
 292: 11 6 16 20`
 	want := int64(11387)
-	eqs := ParseEquations(input)
+	eqs, err := ParseEquations(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	got := FindTotalOfValidEquations(eqs, []Operation{AddOp{}, MulOp{}, ConcatOp{}})
 
 	if got != want {
@@ -185,9 +197,92 @@ func TestConcatOperationReverse(t *testing.T) {
 }
 
 func BenchmarkFindTotalOfValidEquationsWithConcat(b *testing.B) {
-	eqs := ParseEquations(input)
+	input := testutil.LoadOrSkip(b, 7)
+	eqs, err := ParseEquations(input)
+	if err != nil {
+		b.Fatal(err)
+	}
 	b.StartTimer()
 	for i := 0; i < b.N; i++ {
 		FindTotalOfValidEquations(eqs, []Operation{AddOp{}, MulOp{}, ConcatOp{}})
 	}
 }
+
+func TestParseEquationsErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"missing colon", "190: 10 19\n3267 81 40 27", `line 2: "3267 81 40 27" is not an equation like 190: 10 19`},
+		{"total is not a number", "19x: 10 19", `line 1, column 1: invalid number "19x"`},
+		{"number is not a number", "190: 10 19\n83: 17  a5", `line 2, column 9: invalid number "a5"`},
+		{"number is zero", "83: 17 0", "line 1, column 8: number 0 is not positive"},
+		{"no numbers", "83: ", "line 1: equation has no numbers"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := ParseEquations(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+// Tries every combination of operations, evaluated from left to right.
+func bruteForceTotal(eqs []Equation, withConcat bool) int64 {
+	numOps := 2
+	if withConcat {
+		numOps = 3
+	}
+
+	result := int64(0)
+	for _, eq := range eqs {
+		combinations := 1
+		for range len(eq.Numbers) - 1 {
+			combinations *= numOps
+		}
+
+		for combination := range combinations {
+			ops := combination
+			total := eq.Numbers[0]
+			for _, num := range eq.Numbers[1:] {
+				switch ops % numOps {
+				case 0:
+					total += num
+				case 1:
+					total *= num
+				case 2:
+					total, _ = strconv.ParseInt(fmt.Sprintf("%d%d", total, num), 10, 64)
+				}
+				ops /= numOps
+			}
+			if total == eq.Total {
+				result += eq.Total
+				break
+			}
+		}
+	}
+	return result
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 10, 6, 100) }
+	proptest.CheckParts(t, 7, generate, func(input string) (any, any) {
+		eqs, err := ParseEquations(input)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		return bruteForceTotal(eqs, false), bruteForceTotal(eqs, true)
+	})
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 7, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 7, 2)
+}
@@ -0,0 +1,30 @@
+package day8
+
+import (
+	"math/rand/v2"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+)
+
+const frequencies = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Generate returns a map of the given size with the given number of
+// frequencies, each with 3 or 4 antennas, on distinct cells. There must be
+// enough cells for the antennas.
+func Generate(rng *rand.Rand, size, kinds int) string {
+	cells := grid.New(size, size, '.')
+	free := rng.Perm(size * size)
+	for _, frequency := range rng.Perm(len(frequencies))[:kinds] {
+		for range 3 + rng.IntN(2) {
+			cell := free[0]
+			free = free[1:]
+			cells.Set(Position{X: cell % size, Y: cell / size}, rune(frequencies[frequency]))
+		}
+	}
+	return cells.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 50, 40)
+}
@@ -1,38 +1,28 @@
-package main
+package day8
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
 	"slices"
-	"strings"
-)
-
-//go:embed input.txt
-var input string
+	"unicode"
 
-type Position struct {
-	X, Y int
-}
-
-type Slope struct {
-	Dx, Dy int
-}
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
 
-type Size struct {
-	Width, Heignt int
-}
+type (
+	Position = grid.Position
+	Slope    = grid.Direction
+	Size     = grid.Size
+)
 
 func FindSlope(pos1, pos2 Position) Slope {
-	return Slope{pos1.X - pos2.X, pos1.Y - pos2.Y}
-}
-
-func (pos Position) AlongSlope(slope Slope, mul int) Position {
-	return Position{pos.X + slope.Dx*mul, pos.Y + slope.Dy*mul}
+	return pos1.Sub(pos2)
 }
 
 func FindAntiNodeLocations(node1, node2 Position, size Size) []Position {
 	slope := FindSlope(node1, node2)
-	return []Position{node1.AlongSlope(slope, 1), node2.AlongSlope(slope, -1)}
+	return []Position{node1.MoveAlongN(slope, 1), node2.MoveAlongN(slope, -1)}
 }
 
 func FindAllPointsAlongSlope(node1, node2 Position, size Size) []Position {
@@ -40,15 +30,15 @@ func FindAllPointsAlongSlope(node1, node2 Position, size Size) []Position {
 	antiNodes := make([]Position, 0)
 
 	for i := 0; ; i++ {
-		antiNode := node1.AlongSlope(slope, i)
-		if !antiNode.IsInBounds(size) {
+		antiNode := node1.MoveAlongN(slope, i)
+		if !size.IsInBounds(antiNode) {
 			break
 		}
 		antiNodes = append(antiNodes, antiNode)
 	}
 	for i := 0; ; i++ {
-		antiNode := node2.AlongSlope(slope, -i)
-		if !antiNode.IsInBounds(size) {
+		antiNode := node2.MoveAlongN(slope, -i)
+		if !size.IsInBounds(antiNode) {
 			break
 		}
 		antiNodes = append(antiNodes, antiNode)
@@ -72,30 +62,30 @@ type Grid struct {
 	Nodes map[rune][]Position
 }
 
-func (pos Position) IsInBounds(size Size) bool {
-	return pos.X >= 0 && pos.X < size.Width && pos.Y >= 0 && pos.Y < size.Heignt
+// Antennas are marked by a letter or a digit, and the empty places by '.'.
+func parseCell(char rune) (rune, error) {
+	if char != '.' && !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+		return 0, fmt.Errorf("unexpected %q, want '.' or an antenna", char)
+	}
+	return char, nil
 }
 
-func ReadInputGrid(input string) Grid {
-	nodePositions := make(map[rune][]Position)
+func ReadInputGrid(input string) (Grid, error) {
+	cells, err := grid.Parse(input, parseCell)
+	if err != nil {
+		return Grid{}, err
+	}
 
-	lines := strings.Split(input, "\n")
-	for i, line := range lines {
-		for j, char := range line {
-			if char != '.' {
-				positions, ok := nodePositions[char]
-				if !ok {
-					positions = make([]Position, 0)
-				}
-				positions = append(positions, Position{j, i})
-				nodePositions[char] = positions
-			}
+	nodePositions := make(map[rune][]Position)
+	for pos, char := range cells.All() {
+		if char != '.' {
+			nodePositions[char] = append(nodePositions[char], pos)
 		}
 	}
 	return Grid{
-		Size:  Size{len(lines[0]), len(lines)},
+		Size:  cells.Size,
 		Nodes: nodePositions,
-	}
+	}, nil
 }
 
 func FindAllAntiNodes(grid Grid, findNodesFn func(node1, node2 Position, size Size) []Position) []Position {
@@ -105,7 +95,7 @@ func FindAllAntiNodes(grid Grid, findNodesFn func(node1, node2 Position, size Si
 		if slices.Contains(antiNodes, node) {
 			return
 		}
-		if !node.IsInBounds(grid.Size) {
+		if !grid.Size.IsInBounds(node) {
 			return
 		}
 		antiNodes = append(antiNodes, node)
@@ -123,8 +113,23 @@ func FindAllAntiNodes(grid Grid, findNodesFn func(node1, node2 Position, size Si
 	return antiNodes
 }
 
-func main() {
-	grid := ReadInputGrid(input)
-	fmt.Println("Part 1 Solution:", len(FindAllAntiNodes(grid, FindAntiNodeLocations)))
-	fmt.Println("Part 2 Solution:", len(FindAllAntiNodes(grid, FindAllPointsAlongSlope)))
+func init() {
+	registry.Register(registry.Day{
+		Number: 8,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			grid, err := ReadInputGrid(input)
+			if err != nil {
+				return nil, err
+			}
+			return len(FindAllAntiNodes(grid, FindAntiNodeLocations)), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			grid, err := ReadInputGrid(input)
+			if err != nil {
+				return nil, err
+			}
+			return len(FindAllAntiNodes(grid, FindAllPointsAlongSlope)), nil
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,9 +1,11 @@
-package main
+package day8
 
 import (
 	"reflect"
 	"slices"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
 )
 
 func CheckIfNodesAreAllSame(t *testing.T, got, want []Position) {
@@ -34,33 +36,33 @@ func TestAntiNodeLocation(t *testing.T) {
 	}{
 		{
 			Name:          "if nodes are same position, antinodes are also same",
-			FirstNode:     Position{1, 1},
-			SecondNode:    Position{1, 1},
-			WantAntiNodes: [2]Position{{1, 1}, {1, 1}},
+			FirstNode:     Position{X: 1, Y: 1},
+			SecondNode:    Position{X: 1, Y: 1},
+			WantAntiNodes: [2]Position{{X: 1, Y: 1}, {X: 1, Y: 1}},
 		},
 		{
 			Name:          "if nodes are one off vertically, antinodes are also off vertically",
-			FirstNode:     Position{1, 3},
-			SecondNode:    Position{1, 4},
-			WantAntiNodes: [2]Position{{1, 2}, {1, 5}},
+			FirstNode:     Position{X: 1, Y: 3},
+			SecondNode:    Position{X: 1, Y: 4},
+			WantAntiNodes: [2]Position{{X: 1, Y: 2}, {X: 1, Y: 5}},
 		},
 		{
 			Name:          "if nodes are one off horizontally, antinodes are also off horizontally",
-			FirstNode:     Position{3, 1},
-			SecondNode:    Position{4, 1},
-			WantAntiNodes: [2]Position{{5, 1}, {2, 1}},
+			FirstNode:     Position{X: 3, Y: 1},
+			SecondNode:    Position{X: 4, Y: 1},
+			WantAntiNodes: [2]Position{{X: 5, Y: 1}, {X: 2, Y: 1}},
 		},
 		{
 			Name:          "node from example",
-			FirstNode:     Position{8, 1},
-			SecondNode:    Position{5, 2},
-			WantAntiNodes: [2]Position{{11, 0}, {2, 3}},
+			FirstNode:     Position{X: 8, Y: 1},
+			SecondNode:    Position{X: 5, Y: 2},
+			WantAntiNodes: [2]Position{{X: 11, Y: 0}, {X: 2, Y: 3}},
 		},
 	}
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			got := FindAntiNodeLocations(testcase.FirstNode, testcase.SecondNode, Size{100, 100})
+			got := FindAntiNodeLocations(testcase.FirstNode, testcase.SecondNode, Size{Width: 100, Height: 100})
 			CheckIfNodesAreAllSame(t, got[:], testcase.WantAntiNodes[:])
 		})
 	}
@@ -80,14 +82,17 @@ func TestReadInputGrid(t *testing.T) {
 ............
 ............`
 	want := Grid{
-		Size: Size{12, 12},
+		Size: Size{Width: 12, Height: 12},
 		Nodes: map[rune][]Position{
-			'0': {{8, 1}, {5, 2}, {7, 3}, {4, 4}},
-			'A': {{6, 5}, {8, 8}, {9, 9}},
+			'0': {{X: 8, Y: 1}, {X: 5, Y: 2}, {X: 7, Y: 3}, {X: 4, Y: 4}},
+			'A': {{X: 6, Y: 5}, {X: 8, Y: 8}, {X: 9, Y: 9}},
 		},
 	}
 
-	got := ReadInputGrid(input)
+	got, err := ReadInputGrid(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 
 	if !reflect.DeepEqual(want, got) {
 		t.Errorf("Got wrong output: got %v, want %v", got, want)
@@ -109,7 +114,10 @@ func TestFindAllAntiNodes(t *testing.T) {
 ............`
 	wantLen := 14
 
-	grid := ReadInputGrid(input)
+	grid, err := ReadInputGrid(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	antiNodes := FindAllAntiNodes(grid, FindAntiNodeLocations)
 
 	if len(antiNodes) != wantLen {
@@ -119,30 +127,45 @@ func TestFindAllAntiNodes(t *testing.T) {
 	antiNodes = FindAllAntiNodes(grid, FindAllPointsAlongSlope)
 	wantLen = 34
 	want := []Position{
-		{0, 0}, {1, 0}, {6, 0}, {11, 0},
-		{1, 1}, {3, 1}, {8, 1},
-		{2, 2}, {4, 2}, {5, 2}, {10, 2},
-		{2, 3}, {3, 3}, {7, 3},
-		{4, 4}, {9, 4},
-		{1, 5}, {5, 5}, {6, 5}, {11, 5},
-		{3, 6}, {6, 6},
-		{0, 7}, {5, 7}, {7, 7},
-		{2, 8}, {8, 8},
-		{4, 9}, {9, 9},
-		{1, 10}, {10, 10},
-		{3, 11}, {10, 11}, {11, 11},
+		{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 6, Y: 0}, {X: 11, Y: 0},
+		{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 8, Y: 1},
+		{X: 2, Y: 2}, {X: 4, Y: 2}, {X: 5, Y: 2}, {X: 10, Y: 2},
+		{X: 2, Y: 3}, {X: 3, Y: 3}, {X: 7, Y: 3},
+		{X: 4, Y: 4}, {X: 9, Y: 4},
+		{X: 1, Y: 5}, {X: 5, Y: 5}, {X: 6, Y: 5}, {X: 11, Y: 5},
+		{X: 3, Y: 6}, {X: 6, Y: 6},
+		{X: 0, Y: 7}, {X: 5, Y: 7}, {X: 7, Y: 7},
+		{X: 2, Y: 8}, {X: 8, Y: 8},
+		{X: 4, Y: 9}, {X: 9, Y: 9},
+		{X: 1, Y: 10}, {X: 10, Y: 10},
+		{X: 3, Y: 11}, {X: 10, Y: 11}, {X: 11, Y: 11},
 	}
 
 	CheckIfNodesAreAllSame(t, antiNodes, want)
 }
 
 func TestFindAllPointsAlongSlope(t *testing.T) {
-	pos1 := Position{4, 5}
-	pos2 := Position{3, 3}
-	size := Size{10, 10}
-	want := []Position{{2, 1}, {5, 7}, {6, 9}, {4, 5}, {3, 3}}
+	pos1 := Position{X: 4, Y: 5}
+	pos2 := Position{X: 3, Y: 3}
+	size := Size{Width: 10, Height: 10}
+	want := []Position{{X: 2, Y: 1}, {X: 5, Y: 7}, {X: 6, Y: 9}, {X: 4, Y: 5}, {X: 3, Y: 3}}
 
 	got := FindAllPointsAlongSlope(pos1, pos2, size)
 
 	CheckIfNodesAreAllSame(t, got, want)
 }
+
+func TestReadInputGridErrors(t *testing.T) {
+	_, err := ReadInputGrid("....\n..0.\n.#..")
+	if want := `line 3, column 2: unexpected '#', want '.' or an antenna`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 8, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 8, 2)
+}
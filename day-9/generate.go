@@ -0,0 +1,25 @@
+package day9
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Generate returns a disk map of the given number of files. As in the
+// puzzle, the files have 1 to 9 blocks, and the gaps between them 0 to 9.
+func Generate(rng *rand.Rand, files int) string {
+	var builder strings.Builder
+	for i := range files {
+		if i > 0 {
+			builder.WriteByte(byte('0' + rng.IntN(10)))
+		}
+		builder.WriteByte(byte('1' + rng.IntN(9)))
+	}
+	builder.WriteByte('\n')
+	return builder.String()
+}
+
+// GenerateInput returns an input of the size of the real ones.
+func GenerateInput(rng *rand.Rand) string {
+	return Generate(rng, 10000)
+}
@@ -1,13 +1,14 @@
-package main
+package day9
 
 import (
-	_ "embed"
+	"context"
 	"fmt"
-	"strconv"
-)
+	"slices"
 
-//go:embed input.txt
-var input string
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
 
 // The disk contains either -1 or a value at specific id.
 // -1 means empty, otherwise, value is the file id.
@@ -41,10 +42,33 @@ func RearrangeDiskUsingFragmentation(disk []int) []int {
 	return newDisk
 }
 
-func GetDiskFromInput(input string) []int {
+// Read the sizes of the disk map, which is a single line of digits.
+func readSizes(input string) ([]int, error) {
+	lines := parse.Lines(input)
+	if len(lines) > 1 {
+		return nil, parse.Errorf(2, 0, "want the disk map on a single line")
+	}
+
+	sizes := make([]int, 0)
+	for _, line := range lines {
+		for i, char := range line {
+			if char < '0' || char > '9' {
+				return nil, parse.Errorf(1, i+1, "unexpected %q, want a digit", char)
+			}
+			sizes = append(sizes, int(char-'0'))
+		}
+	}
+	return sizes, nil
+}
+
+func GetDiskFromInput(input string) ([]int, error) {
+	sizes, err := readSizes(input)
+	if err != nil {
+		return nil, err
+	}
+
 	disk := make([]int, 0)
-	for i := range input {
-		size, _ := strconv.ParseInt(input[i:i+1], 10, 8)
+	for i, size := range sizes {
 		data := make([]int, size)
 		valueToFill := -1
 		if i%2 == 0 {
@@ -56,14 +80,10 @@ func GetDiskFromInput(input string) []int {
 		}
 		disk = append(disk, data...)
 	}
-	return disk
+	return disk, nil
 }
 
 func ComputeDiskChecksumPart1(disk []int) int {
-	if len(input) <= 2 {
-		return 0
-	}
-
 	newDisk := RearrangeDiskUsingFragmentation(disk)
 
 	total := 0
@@ -96,13 +116,36 @@ func (fd FileData) CheckSum() int {
 	return findSumOfIndices(fd.Start, fd.Size) * fd.ID
 }
 
-// The disk contains either -1 or a value at specific id.
-// -1 means empty, otherwise, value is the file id.
+// Disk holds the files and the gaps of a disk, for moving whole files.
+type Disk struct {
+	Files []FileData
+	Gaps  []Gap
+
+	// Told about every file moved or kept in place, if set.
+	Observer trace.Observer[DiskState]
+}
+
+// The disk after a file was looked at, as given to the observer. Files has the
+// files not looked at yet, followed by the ones already placed.
+type DiskState struct {
+	Files []FileData
+	Gaps  []Gap
+}
+
+func RearrangeDiskByCopyWholeFiles(files []FileData, gaps []Gap) []FileData {
+	disk := Disk{Files: files, Gaps: gaps}
+	newFiles, _ := disk.RearrangeWholeFiles(context.Background())
+	return newFiles
+}
+
 // Rearrange the disk using following logic:
 //
 //	Starting from end, check if entire file can be moved to some gap
 //	For specific file, just check from leftmost gap, and move to first gap where it fits
-func RearrangeDiskByCopyWholeFiles(files []FileData, gaps []Gap) []FileData {
+//
+// Every file scans the gaps from the left, so ctx is checked once per file.
+func (disk *Disk) RearrangeWholeFiles(ctx context.Context) ([]FileData, error) {
+	files, gaps := disk.Files, disk.Gaps
 	newFiles := make([]FileData, 0, len(files))
 
 	findGapThatFits := func(size int) (*Gap, int) {
@@ -115,6 +158,9 @@ func RearrangeDiskByCopyWholeFiles(files []FileData, gaps []Gap) []FileData {
 	}
 
 	for i := len(files) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		file := files[i]
 		gap, gapIndex := findGapThatFits(file.Size)
 		if gap != nil && gap.Start <= file.Start {
@@ -124,45 +170,77 @@ func RearrangeDiskByCopyWholeFiles(files []FileData, gaps []Gap) []FileData {
 			gaps[gapIndex] = *gap
 		}
 		newFiles = append(newFiles, file)
+
+		if disk.Observer != nil {
+			action := fmt.Sprintf("keep file %d", file.ID)
+			if file.Start != files[i].Start {
+				action = fmt.Sprintf("move file %d to %d", file.ID, file.Start)
+			}
+			disk.Observer.Observe(trace.Step[DiskState]{
+				Index:  len(files) - 1 - i,
+				Action: action,
+				State:  DiskState{slices.Concat(files[:i], newFiles), slices.Clone(gaps)},
+			})
+		}
 	}
 
-	return newFiles
+	return newFiles, nil
 }
 
-func ReadFilesAndGapsFromInput(input string) ([]FileData, []Gap) {
+func ReadFilesAndGapsFromInput(input string) ([]FileData, []Gap, error) {
+	sizes, err := readSizes(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	files := make([]FileData, 0)
 	gaps := make([]Gap, 0)
 	startIndex := 0
-	for i := range input {
-		size, _ := strconv.ParseInt(input[i:i+1], 10, 8)
+	for i, size := range sizes {
 		if i%2 == 0 {
-			files = append(files, FileData{ID: i / 2, Size: int(size), Start: startIndex})
+			files = append(files, FileData{ID: i / 2, Size: size, Start: startIndex})
 		} else {
-			gaps = append(gaps, Gap{Size: int(size), Start: startIndex})
+			gaps = append(gaps, Gap{Size: size, Start: startIndex})
 		}
-		startIndex += int(size)
+		startIndex += size
 	}
-	return files, gaps
+	return files, gaps, nil
 }
 
 func ComputeDiskChecksumPart2(files []FileData, gaps []Gap) int {
-	if len(input) <= 2 {
-		return 0
-	}
-
-	newFiles := RearrangeDiskByCopyWholeFiles(files, gaps)
+	return checksumOfFiles(RearrangeDiskByCopyWholeFiles(files, gaps))
+}
 
+func checksumOfFiles(files []FileData) int {
 	total := 0
-	for _, file := range newFiles {
+	for _, file := range files {
 		total += file.CheckSum()
 	}
 	return total
 }
 
-func main() {
-	disk := GetDiskFromInput(input)
-	fmt.Println("Solution to part 1:", ComputeDiskChecksumPart1(disk))
-
-	files, gaps := ReadFilesAndGapsFromInput(input)
-	fmt.Println("Solution to part 2:", ComputeDiskChecksumPart2(files, gaps))
+func init() {
+	registry.Register(registry.Day{
+		Number: 9,
+		Part1: func(ctx context.Context, input string) (any, error) {
+			disk, err := GetDiskFromInput(input)
+			if err != nil {
+				return nil, err
+			}
+			return ComputeDiskChecksumPart1(disk), nil
+		},
+		Part2: func(ctx context.Context, input string) (any, error) {
+			files, gaps, err := ReadFilesAndGapsFromInput(input)
+			if err != nil {
+				return nil, err
+			}
+			disk := Disk{Files: files, Gaps: gaps}
+			newFiles, err := disk.RearrangeWholeFiles(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return checksumOfFiles(newFiles), nil
+		},
+		Generate: GenerateInput,
+	})
 }
@@ -1,10 +1,26 @@
-package main
+package day9
 
 import (
+	"context"
+	"math/rand/v2"
 	"reflect"
+	"slices"
 	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/internal/testutil"
+	"github.com/tejesh-kaliki/advent-of-code-2024/proptest"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
 )
 
+func mustGetDiskFromInput(t *testing.T, input string) []int {
+	t.Helper()
+	disk, err := GetDiskFromInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	return disk
+}
+
 func TestComputeDiskChecksum(t *testing.T) {
 	testcases := []struct {
 		Name  string
@@ -55,7 +71,7 @@ func TestComputeDiskChecksum(t *testing.T) {
 
 	for _, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			got := ComputeDiskChecksumPart1(GetDiskFromInput(testcase.Input))
+			got := ComputeDiskChecksumPart1(mustGetDiskFromInput(t, testcase.Input))
 			if got != testcase.Want {
 				t.Errorf("Wrong output: got %d, want %d", got, testcase.Want)
 			}
@@ -96,7 +112,7 @@ func TestRearrangeDiskUsingFragmentation(t *testing.T) {
 		},
 		{
 			Name:     "example from aoc",
-			Disk:     GetDiskFromInput("2333133121414131402"),
+			Disk:     mustGetDiskFromInput(t, "2333133121414131402"),
 			WantDisk: []int{0, 0, 9, 9, 8, 1, 1, 1, 8, 8, 8, 2, 7, 7, 7, 3, 3, 3, 6, 4, 4, 6, 5, 5, 5, 5, 6, 6},
 		},
 	}
@@ -113,7 +129,10 @@ func TestRearrangeDiskUsingFragmentation(t *testing.T) {
 
 func TestRearrangeDiskAsWholeFiles(t *testing.T) {
 	input := "2333133121414131402"
-	files, gaps := ReadFilesAndGapsFromInput(input)
+	files, gaps, err := ReadFilesAndGapsFromInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	testcases := []struct {
 		Name      string
 		Files     []FileData
@@ -161,10 +180,36 @@ func TestRearrangeDiskAsWholeFiles(t *testing.T) {
 	}
 }
 
+func TestObserveRearrangeDisk(t *testing.T) {
+	files := []FileData{{0, 0, 1}, {1, 3, 2}, {2, 6, 1}}
+	gaps := []Gap{{1, 3}, {5, 1}}
+	recorder := &trace.Recorder[DiskState]{}
+
+	disk := Disk{Files: files, Gaps: gaps, Observer: recorder}
+	if _, err := disk.RearrangeWholeFiles(context.Background()); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	wantActions := []string{"move file 2 to 1", "move file 1 to 2", "keep file 0"}
+	if got := recorder.Actions(); !reflect.DeepEqual(got, wantActions) {
+		t.Errorf("Got wrong actions: got %v, want %v", got, wantActions)
+	}
+	wantFirst := DiskState{
+		Files: []FileData{{0, 0, 1}, {1, 3, 2}, {2, 1, 1}},
+		Gaps:  []Gap{{2, 2}, {5, 1}},
+	}
+	if got := recorder.Steps[0].State; !reflect.DeepEqual(got, wantFirst) {
+		t.Errorf("Got wrong first state: got %v, want %v", got, wantFirst)
+	}
+}
+
 func TestComputeDiskChecksumPart2(t *testing.T) {
 	input := "2333133121414131402"
 	want := 2858
-	files, gaps := ReadFilesAndGapsFromInput(input)
+	files, gaps, err := ReadFilesAndGapsFromInput(input)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
 	got := ComputeDiskChecksumPart2(files, gaps)
 
 	if got != want {
@@ -172,17 +217,122 @@ func TestComputeDiskChecksumPart2(t *testing.T) {
 	}
 }
 
+// Moves one block at a time, from the last file block to the first free one.
+func bruteForceFragmented(disk []int) int {
+	disk = slices.Clone(disk)
+	for {
+		free := slices.Index(disk, -1)
+		last := len(disk) - 1
+		for last >= 0 && disk[last] == -1 {
+			last--
+		}
+		if free < 0 || free > last {
+			break
+		}
+		disk[free], disk[last] = disk[last], -1
+	}
+	return checksum(disk)
+}
+
+// Moves the files in decreasing order of ID to the first span of free blocks
+// that fits them, on the left of the file.
+func bruteForceWholeFiles(disk []int) int {
+	disk = slices.Clone(disk)
+	for id := slices.Max(disk); id >= 0; id-- {
+		start := slices.Index(disk, id)
+		size := 0
+		for start+size < len(disk) && disk[start+size] == id {
+			size++
+		}
+
+		for free := 0; free+size <= start; free++ {
+			if slices.ContainsFunc(disk[free:free+size], func(block int) bool { return block != -1 }) {
+				continue
+			}
+			for i := range size {
+				disk[free+i], disk[start+i] = id, -1
+			}
+			break
+		}
+	}
+	return checksum(disk)
+}
+
+func checksum(disk []int) int {
+	total := 0
+	for i, id := range disk {
+		if id != -1 {
+			total += i * id
+		}
+	}
+	return total
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	generate := func(rng *rand.Rand) string { return Generate(rng, 1+rng.IntN(30)) }
+	proptest.CheckParts(t, 9, generate, func(input string) (any, any) {
+		disk, err := GetDiskFromInput(input)
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		return bruteForceFragmented(disk), bruteForceWholeFiles(disk)
+	})
+}
+
 func BenchmarkPart1Solution(b *testing.B) {
-	disk := GetDiskFromInput(input)
+	input := testutil.LoadOrSkip(b, 9)
+	disk, err := GetDiskFromInput(input)
+	if err != nil {
+		b.Fatal(err)
+	}
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		ComputeDiskChecksumPart1(disk)
 	}
 }
 func BenchmarkPart2Solution(b *testing.B) {
-	files, gaps := ReadFilesAndGapsFromInput(input)
+	input := testutil.LoadOrSkip(b, 9)
+	files, gaps, err := ReadFilesAndGapsFromInput(input)
+	if err != nil {
+		b.Fatal(err)
+	}
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		ComputeDiskChecksumPart2(files, gaps)
 	}
 }
+
+func TestReadInputErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"character that is not a digit", "2333x33", `line 1, column 5: unexpected 'x', want a digit`},
+		{"more than one line", "2333\n133", "line 2: want the disk map on a single line"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, _, err := ReadFilesAndGapsFromInput(testcase.Input)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestTrailingNewlineIsIgnored(t *testing.T) {
+	disk := mustGetDiskFromInput(t, "12345\r\n")
+	if got := ComputeDiskChecksumPart1(disk); got != 60 {
+		t.Errorf("Wrong output: got %d, want %d", got, 60)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	testutil.BenchPart(b, 9, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	testutil.BenchPart(b, 9, 2)
+}
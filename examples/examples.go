@@ -0,0 +1,162 @@
+// Package examples turns the examples of a saved puzzle description into
+// golden fixtures, which the tests of a day check its solvers against with
+// testutil.Examples.
+//
+// The fixtures of a day are kept in its testdata directory: every example
+// block in example-N.txt, and in examples.json, the example and the expected
+// answer of each part.
+package examples
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FixturesFile is the name of the file listing the example of each part.
+const FixturesFile = "examples.json"
+
+// Puzzle holds the examples found in a puzzle description.
+type Puzzle struct {
+	// Examples are the texts of the <pre><code> blocks, in order.
+	Examples []string
+	// Parts are the parts described in the page, at most two.
+	Parts []Part
+}
+
+// Part holds the example of a part and its expected answer.
+type Part struct {
+	// Example is the index of the example in Puzzle.Examples.
+	Example int
+	Answer  string
+}
+
+// Fixture is an entry of the fixtures file.
+type Fixture struct {
+	Input  string `json:"input"`
+	Answer string `json:"answer"`
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	examplePattern = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	answerPattern  = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>|<em><code>(.*?)</code></em>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Extract finds the examples and the expected answers in the HTML of a puzzle
+// description. Every part of the puzzle is an <article>, whose last
+// emphasized code is the answer to its example. The example of a part is the
+// last multi-line block before that answer, which for a second part is often
+// the example of the first part.
+func Extract(page string) (Puzzle, error) {
+	var puzzle Puzzle
+	exampleEnds := make([]int, 0)
+	for _, match := range examplePattern.FindAllStringSubmatchIndex(page, -1) {
+		puzzle.Examples = append(puzzle.Examples, textOf(page[match[2]:match[3]]))
+		exampleEnds = append(exampleEnds, match[1])
+	}
+
+	for _, article := range articlePattern.FindAllStringSubmatchIndex(page, -1) {
+		answers := answerPattern.FindAllStringSubmatchIndex(page[article[2]:article[3]], -1)
+		if len(answers) == 0 {
+			continue
+		}
+		last := answers[len(answers)-1]
+		answerStart := article[2] + last[0]
+		answer := ""
+		if last[2] >= 0 {
+			answer = page[article[2]+last[2] : article[2]+last[3]]
+		} else {
+			answer = page[article[2]+last[4] : article[2]+last[5]]
+		}
+
+		example := -1
+		for i, end := range exampleEnds {
+			if end <= answerStart && strings.Count(puzzle.Examples[i], "\n") > 1 {
+				example = i
+			}
+		}
+		if example < 0 {
+			return Puzzle{}, fmt.Errorf("part %d has no example before its answer", len(puzzle.Parts)+1)
+		}
+		puzzle.Parts = append(puzzle.Parts, Part{Example: example, Answer: textOf(answer)})
+		if len(puzzle.Parts) == 2 {
+			break
+		}
+	}
+
+	if len(puzzle.Parts) == 0 {
+		return Puzzle{}, errors.New("no part with an answer found, is it a puzzle description?")
+	}
+	return puzzle, nil
+}
+
+// Removes the tags of an HTML fragment, like the <em> of highlighted cells.
+func textOf(fragment string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(fragment, ""))
+}
+
+// ExampleFile returns the name of the file of an example, given its index.
+func ExampleFile(index int) string {
+	return fmt.Sprintf("example-%d.txt", index+1)
+}
+
+// Write saves the examples of a puzzle and the fixtures file in dir.
+func Write(dir string, puzzle Puzzle) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for i, example := range puzzle.Examples {
+		if err := os.WriteFile(filepath.Join(dir, ExampleFile(i)), []byte(example), 0o644); err != nil {
+			return err
+		}
+	}
+
+	fixtures := make(map[string]Fixture)
+	for i, part := range puzzle.Parts {
+		fixtures[partKey(i+1)] = Fixture{Input: ExampleFile(part.Example), Answer: part.Answer}
+	}
+	data, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, FixturesFile), append(data, '\n'), 0o644)
+}
+
+func partKey(part int) string {
+	return fmt.Sprintf("part%d", part)
+}
+
+// Load reads the example input and the expected answer of a part from the
+// fixtures in dir. The found result is false if the part has no fixture, or
+// if there is no fixtures file at all.
+func Load(dir string, part int) (input, answer string, found bool, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, FixturesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, err
+	}
+
+	var fixtures map[string]Fixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return "", "", false, fmt.Errorf("reading %s: %w", FixturesFile, err)
+	}
+	fixture, found := fixtures[partKey(part)]
+	if !found {
+		return "", "", false, nil
+	}
+
+	example, err := os.ReadFile(filepath.Join(dir, fixture.Input))
+	if err != nil {
+		return "", "", false, err
+	}
+	return string(example), fixture.Answer, true, nil
+}
@@ -0,0 +1,103 @@
+package examples
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	page, err := os.ReadFile("testdata/day-1.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Extract(string(page))
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := Puzzle{
+		Examples: []string{"3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n"},
+		Parts:    []Part{{Example: 0, Answer: "11"}, {Example: 0, Answer: "31"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong puzzle: got %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractPicksLastExampleBeforeAnswer(t *testing.T) {
+	page := `<article class="day-desc">
+<pre><code>&lt;^^&gt;</code></pre>
+<pre><code>#.<em>O</em>
+.@#
+##.
+</code></pre>
+<p>The sum is <code>2</code> at first, then <code><em>10092</em></code>.</p>
+<pre><code>...
+...
+</code></pre>
+</article>`
+
+	got, err := Extract(page)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	want := Puzzle{
+		Examples: []string{"<^^>", "#.O\n.@#\n##.\n", "...\n...\n"},
+		Parts:    []Part{{Example: 1, Answer: "10092"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong puzzle: got %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Page    string
+		WantErr string
+	}{
+		{"no answer", "<article><p>Nothing here</p></article>", "no part with an answer found, is it a puzzle description?"},
+		{"no example", "<article><p>It is <code><em>4</em></code></p></article>", "part 1 has no example before its answer"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := Extract(testcase.Page)
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	puzzle := Puzzle{
+		Examples: []string{"1\n2\n", "3\n4\n"},
+		Parts:    []Part{{Example: 1, Answer: "7"}},
+	}
+	if err := Write(dir, puzzle); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	input, answer, found, err := Load(dir, 1)
+	if err != nil || !found {
+		t.Fatalf("Got unexpected error: %v, found %v", err, found)
+	}
+	if input != "3\n4\n" || answer != "7" {
+		t.Errorf("Got wrong fixture: got %q and %q, want %q and %q", input, answer, "3\n4\n", "7")
+	}
+
+	if _, _, found, err := Load(dir, 2); err != nil || found {
+		t.Errorf("Got a fixture for part 2: found %v, err %v", found, err)
+	}
+}
+
+func TestLoadWithoutFixtures(t *testing.T) {
+	if _, _, found, err := Load(t.TempDir(), 1); err != nil || found {
+		t.Errorf("Got a fixture without fixtures file: found %v, err %v", found, err)
+	}
+}
@@ -1,10 +1,3 @@
 module github.com/tejesh-kaliki/advent-of-code-2024
 
 go 1.23.2
-
-require github.com/llgcode/draw2d v0.0.0-20240627062922-0ed1ff131195
-
-require (
-	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
-	golang.org/x/image v0.18.0 // indirect
-)
@@ -0,0 +1,263 @@
+// Package grid has the types shared by the puzzles played on a 2D grid:
+// positions, directions and a generic grid of cells.
+//
+// X grows towards the right and Y grows downwards, so that Y is the line of the
+// input and X is the column within the line.
+package grid
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/parse"
+)
+
+type Position struct {
+	X, Y int
+}
+
+func (pos Position) String() string {
+	return fmt.Sprintf("%d,%d", pos.X, pos.Y)
+}
+
+func (pos Position) MoveAlong(dir Direction) Position {
+	return Position{pos.X + dir.Dx, pos.Y + dir.Dy}
+}
+
+// Move [n] steps along the direction. Negative [n] moves in the opposite direction.
+func (pos Position) MoveAlongN(dir Direction, n int) Position {
+	return Position{pos.X + dir.Dx*n, pos.Y + dir.Dy*n}
+}
+
+// Returns the direction to move from [from] to reach [pos] in a single step.
+func (pos Position) Sub(from Position) Direction {
+	return Direction{pos.X - from.X, pos.Y - from.Y}
+}
+
+func ManhattanDistance(pos1, pos2 Position) int {
+	return abs(pos1.X-pos2.X) + abs(pos1.Y-pos2.Y)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+type Direction struct {
+	Dx, Dy int
+}
+
+var (
+	UP    = Direction{0, -1}
+	DOWN  = Direction{0, 1}
+	RIGHT = Direction{1, 0}
+	LEFT  = Direction{-1, 0}
+
+	UP_RIGHT   = Direction{1, -1}
+	DOWN_RIGHT = Direction{1, 1}
+	DOWN_LEFT  = Direction{-1, 1}
+	UP_LEFT    = Direction{-1, -1}
+
+	// The 4 directions in clockwise order, starting from UP
+	ALL_DIRS = []Direction{UP, RIGHT, DOWN, LEFT}
+	// The 8 directions including diagonals in clockwise order, starting from UP
+	ALL_DIRS_8 = []Direction{UP, UP_RIGHT, RIGHT, DOWN_RIGHT, DOWN, DOWN_LEFT, LEFT, UP_LEFT}
+)
+
+// Rotate the direction by 90 degrees clockwise.
+func (dir Direction) Rotate90() Direction {
+	return Direction{-dir.Dy, dir.Dx}
+}
+
+// Rotate the direction by 90 degrees counter clockwise.
+func (dir Direction) RotateCounter90() Direction {
+	return Direction{dir.Dy, -dir.Dx}
+}
+
+func (dir Direction) Reverse() Direction {
+	return Direction{-dir.Dx, -dir.Dy}
+}
+
+// Returns the 2 directions perpendicular to this one.
+func (dir Direction) Perpendicular() [2]Direction {
+	return [2]Direction{dir.Rotate90(), dir.RotateCounter90()}
+}
+
+type Size struct {
+	Width, Height int
+}
+
+func (size Size) IsInBounds(pos Position) bool {
+	return pos.X >= 0 && pos.X < size.Width && pos.Y >= 0 && pos.Y < size.Height
+}
+
+// Iterate over all the positions, line by line.
+func (size Size) Positions() iter.Seq[Position] {
+	return func(yield func(Position) bool) {
+		for y := 0; y < size.Height; y++ {
+			for x := 0; x < size.Width; x++ {
+				if !yield(Position{x, y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+type Grid[T any] struct {
+	Size
+	Cells [][]T
+}
+
+// Create a grid with all the cells set to [fill].
+func New[T any](width, height int, fill T) Grid[T] {
+	cells := make([][]T, height)
+	for y := range cells {
+		cells[y] = make([]T, width)
+		for x := range cells[y] {
+			cells[y][x] = fill
+		}
+	}
+	return Grid[T]{Size{width, height}, cells}
+}
+
+// Create a grid from its lines of cells. The width is the length of the first line.
+func FromCells[T any](cells [][]T) Grid[T] {
+	width := 0
+	if len(cells) > 0 {
+		width = len(cells[0])
+	}
+	return Grid[T]{Size{width, len(cells)}, cells}
+}
+
+// Parse a grid with a cell for each character of the text. All the lines
+// must have the same number of cells.
+func Parse[T any](text string, parseCell func(char rune) (T, error)) (Grid[T], error) {
+	lines := parse.Lines(text)
+	cells := make([][]T, len(lines))
+	for y, line := range lines {
+		row := make([]T, 0, len(line))
+		for _, char := range line {
+			value, err := parseCell(char)
+			if err != nil {
+				return Grid[T]{}, &parse.Error{Line: y + 1, Column: len(row) + 1, Err: err}
+			}
+			row = append(row, value)
+		}
+		if y > 0 && len(row) != len(cells[0]) {
+			return Grid[T]{}, parse.Errorf(y+1, 0, "has %d cells, want %d like the first line", len(row), len(cells[0]))
+		}
+		cells[y] = row
+	}
+	return FromCells(cells), nil
+}
+
+// Parse a grid keeping the characters of the text as they are.
+func ParseRunes(text string) (Grid[rune], error) {
+	return Parse(text, func(char rune) (rune, error) { return char, nil })
+}
+
+// Returns a cell parser that keeps the characters as they are, but fails for
+// any character not in [chars].
+func RunesIn(chars string) func(char rune) (rune, error) {
+	return func(char rune) (rune, error) {
+		if !strings.ContainsRune(chars, char) {
+			return 0, fmt.Errorf("unexpected %q, want one of %q", char, chars)
+		}
+		return char, nil
+	}
+}
+
+func (grid Grid[T]) At(pos Position) T {
+	return grid.Cells[pos.Y][pos.X]
+}
+
+func (grid Grid[T]) Set(pos Position, value T) {
+	grid.Cells[pos.Y][pos.X] = value
+}
+
+// Returns the adjacent positions within the grid, not including diagonals.
+func (grid Grid[T]) Neighbours4(pos Position) []Position {
+	return grid.neighbours(pos, ALL_DIRS)
+}
+
+// Returns the adjacent positions within the grid, including diagonals.
+func (grid Grid[T]) Neighbours8(pos Position) []Position {
+	return grid.neighbours(pos, ALL_DIRS_8)
+}
+
+func (grid Grid[T]) neighbours(pos Position, dirs []Direction) []Position {
+	positions := make([]Position, 0, len(dirs))
+	for _, dir := range dirs {
+		if nextPos := pos.MoveAlong(dir); grid.IsInBounds(nextPos) {
+			positions = append(positions, nextPos)
+		}
+	}
+	return positions
+}
+
+// Iterate over all the cells, line by line.
+func (grid Grid[T]) All() iter.Seq2[Position, T] {
+	return func(yield func(Position, T) bool) {
+		for pos := range grid.Positions() {
+			if !yield(pos, grid.At(pos)) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the positions of all the cells for which [match] returns true.
+func (grid Grid[T]) FindAll(match func(T) bool) []Position {
+	positions := make([]Position, 0)
+	for pos, value := range grid.All() {
+		if match(value) {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+func (grid Grid[T]) Copy() Grid[T] {
+	cells := make([][]T, len(grid.Cells))
+	for y, row := range grid.Cells {
+		cells[y] = make([]T, len(row))
+		copy(cells[y], row)
+	}
+	return Grid[T]{grid.Size, cells}
+}
+
+// Returns a new grid, rotated by 90 degrees clockwise.
+func (grid Grid[T]) Rotate90() Grid[T] {
+	cells := make([][]T, grid.Width)
+	for x := range cells {
+		cells[x] = make([]T, grid.Height)
+		for y := range cells[x] {
+			cells[x][y] = grid.Cells[grid.Height-1-y][x]
+		}
+	}
+	return Grid[T]{Size{grid.Height, grid.Width}, cells}
+}
+
+// Returns the grid as text, with a line for each row of the grid.
+// Runes and bytes are written as characters, other values using fmt.
+func (grid Grid[T]) String() string {
+	var builder strings.Builder
+	for _, row := range grid.Cells {
+		for _, value := range row {
+			switch v := any(value).(type) {
+			case rune:
+				builder.WriteRune(v)
+			case byte:
+				builder.WriteByte(v)
+			default:
+				fmt.Fprint(&builder, v)
+			}
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
@@ -0,0 +1,152 @@
+package grid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDirectionRotate90(t *testing.T) {
+	testcases := []struct {
+		Dir, Want Direction
+	}{
+		{UP, RIGHT},
+		{RIGHT, DOWN},
+		{DOWN, LEFT},
+		{LEFT, UP},
+	}
+
+	for _, testcase := range testcases {
+		if got := testcase.Dir.Rotate90(); got != testcase.Want {
+			t.Errorf("Got wrong direction after rotating %v: got %v, want %v", testcase.Dir, got, testcase.Want)
+		}
+		if got := testcase.Want.RotateCounter90(); got != testcase.Dir {
+			t.Errorf("Got wrong direction after rotating %v back: got %v, want %v", testcase.Want, got, testcase.Dir)
+		}
+	}
+}
+
+func TestPositionMoveAlong(t *testing.T) {
+	pos := Position{3, 4}
+	if got := pos.MoveAlong(UP); got != (Position{3, 3}) {
+		t.Errorf("Got wrong position: got %v, want %v", got, Position{3, 3})
+	}
+	if got := pos.MoveAlongN(RIGHT, -2); got != (Position{1, 4}) {
+		t.Errorf("Got wrong position: got %v, want %v", got, Position{1, 4})
+	}
+	if got := ManhattanDistance(pos, Position{0, 8}); got != 7 {
+		t.Errorf("Got wrong distance: got %d, want %d", got, 7)
+	}
+}
+
+func TestParse(t *testing.T) {
+	grid, err := ParseRunes("#.@\n..#\n")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if grid.Width != 3 || grid.Height != 2 {
+		t.Fatalf("Got wrong size: got %v, want %v", grid.Size, Size{3, 2})
+	}
+	if got := grid.At(Position{2, 0}); got != '@' {
+		t.Errorf("Got wrong cell: got %q, want %q", got, '@')
+	}
+	if got := grid.FindAll(func(r rune) bool { return r == '#' }); !slices.Equal(got, []Position{{0, 0}, {2, 1}}) {
+		t.Errorf("Got wrong positions: got %v", got)
+	}
+	if got := grid.String(); got != "#.@\n..#\n" {
+		t.Errorf("Got wrong text: got %q", got)
+	}
+
+	digits, err := Parse("12\r\n34", func(r rune) (int, error) { return int(r - '0'), nil })
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got := digits.At(Position{1, 1}); got != 4 {
+		t.Errorf("Got wrong cell: got %d, want %d", got, 4)
+	}
+	if got := digits.String(); got != "12\n34\n" {
+		t.Errorf("Got wrong text: got %q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testcases := []struct {
+		Name    string
+		Input   string
+		WantErr string
+	}{
+		{"lines of different length", "...\n..\n...", "line 2: has 2 cells, want 3 like the first line"},
+		{"unexpected character", "...\n.#.\n.x.", `line 3, column 2: unexpected 'x', want one of ".#"`},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := Parse(testcase.Input, RunesIn(".#"))
+			if err == nil || err.Error() != testcase.WantErr {
+				t.Errorf("Got wrong error: got %v, want %s", err, testcase.WantErr)
+			}
+		})
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	grid := New(3, 3, 0)
+
+	testcases := []struct {
+		Name string
+		Got  []Position
+		Want []Position
+	}{
+		{"4 neighbours in the middle", grid.Neighbours4(Position{1, 1}), []Position{{1, 0}, {2, 1}, {1, 2}, {0, 1}}},
+		{"4 neighbours at the corner", grid.Neighbours4(Position{0, 0}), []Position{{1, 0}, {0, 1}}},
+		{"8 neighbours at the corner", grid.Neighbours8(Position{2, 2}), []Position{{2, 1}, {1, 2}, {1, 1}}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if !slices.Equal(testcase.Got, testcase.Want) {
+				t.Errorf("Got wrong neighbours: got %v, want %v", testcase.Got, testcase.Want)
+			}
+		})
+	}
+
+	if got := len(grid.Neighbours8(Position{1, 1})); got != 8 {
+		t.Errorf("Got wrong number of neighbours in the middle: got %d, want %d", got, 8)
+	}
+}
+
+func TestSetOnCopy(t *testing.T) {
+	grid, _ := ParseRunes("ab\ncd")
+	copied := grid.Copy()
+	copied.Set(Position{0, 0}, 'x')
+
+	if grid.At(Position{0, 0}) != 'a' {
+		t.Errorf("Setting a cell of the copy changed the original grid")
+	}
+	if copied.At(Position{0, 0}) != 'x' {
+		t.Errorf("Got wrong cell after set: got %q, want %q", copied.At(Position{0, 0}), 'x')
+	}
+}
+
+func TestRotate90(t *testing.T) {
+	grid, _ := ParseRunes("abc\ndef")
+	rotated := grid.Rotate90()
+
+	if got, want := rotated.String(), "da\neb\nfc\n"; got != want {
+		t.Errorf("Got wrong rotated grid: got %q, want %q", got, want)
+	}
+	if rotated.Size != (Size{2, 3}) {
+		t.Errorf("Got wrong size: got %v, want %v", rotated.Size, Size{2, 3})
+	}
+}
+
+func TestAllIteratesLineByLine(t *testing.T) {
+	grid, _ := ParseRunes("ab\ncd")
+	text := ""
+	for pos, value := range grid.All() {
+		text += pos.String() + "=" + string(value) + " "
+	}
+	if want := "0,0=a 1,0=b 0,1=c 1,1=d "; text != want {
+		t.Errorf("Got wrong order: got %q, want %q", text, want)
+	}
+}
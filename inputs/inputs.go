@@ -0,0 +1,114 @@
+// Package inputs loads the puzzle inputs at runtime. The inputs cannot be
+// committed to the repository, so they are read from a given file, from stdin
+// or from a per-user cache directory.
+package inputs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrMissing is returned when there is no input for a day in the cache.
+var ErrMissing = errors.New("puzzle input not found")
+
+// CacheDir returns the directory where the inputs are stored. It can be
+// changed with the AOC_CACHE_DIR environment variable.
+func CacheDir() (string, error) {
+	if dir := os.Getenv("AOC_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("finding cache directory: %w (set AOC_CACHE_DIR instead)", err)
+	}
+	return filepath.Join(userCacheDir, "advent-of-code-2024"), nil
+}
+
+// DayDir returns the directory of the cache holding the files of a day.
+func DayDir(day int) (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("day-%d", day)), nil
+}
+
+// CachePath returns the path of the input of a day in the cache.
+func CachePath(day int) (string, error) {
+	dir, err := DayDir(day)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "input.txt"), nil
+}
+
+// Load reads the input of a day. If path is empty, the input is read from the
+// cache, and if it is "-", it is read from stdin.
+func Load(day int, path string) (string, error) {
+	switch path {
+	case "-":
+		return Read(os.Stdin)
+	case "":
+		cachePath, err := CachePath(day)
+		if err != nil {
+			return "", err
+		}
+		input, err := readFile(cachePath)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("day %d: %w, save it at %s or pass its path with --input", day, ErrMissing, cachePath)
+		}
+		return input, err
+	default:
+		return readFile(path)
+	}
+}
+
+func readFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return Read(file)
+}
+
+// Read reads a whole input as it is. The line endings are left to the parsers.
+func Read(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("reading input: %w", err)
+	}
+	return string(data), nil
+}
+
+// Save stores the input of a day in the cache. The input is written to a
+// temporary file first, so an interrupted save never leaves a partial input.
+func Save(day int, input string) error {
+	path, err := CachePath(day)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "input-*.txt")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(input); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,85 @@
+package inputs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFromCache(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AOC_CACHE_DIR", dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "day-3"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "day-3", "input.txt"), []byte("mul(1,2)\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(3, "")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got != "mul(1,2)\n" {
+		t.Errorf("Got wrong input: got %q, want %q", got, "mul(1,2)\n")
+	}
+}
+
+func TestLoadMissingInput(t *testing.T) {
+	t.Setenv("AOC_CACHE_DIR", t.TempDir())
+
+	_, err := Load(3, "")
+	if !errors.Is(err, ErrMissing) {
+		t.Fatalf("Got wrong error: got %v, want %v", err, ErrMissing)
+	}
+	if !strings.Contains(err.Error(), "--input") {
+		t.Errorf("Error does not mention the --input flag: %v", err)
+	}
+}
+
+func TestLoadFromPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "example.txt")
+	if err := os.WriteFile(path, []byte("1 2\r\n3 4\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(2, path)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if want := "1 2\r\n3 4\r\n"; got != want {
+		t.Errorf("Got wrong input: got %q, want %q", got, want)
+	}
+
+	if _, err := Load(2, path+".missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Got wrong error for missing file: %v", err)
+	}
+}
+
+func TestSaveToCache(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AOC_CACHE_DIR", dir)
+
+	if err := Save(5, "47|53\n"); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	got, err := Load(5, "")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if got != "47|53\n" {
+		t.Errorf("Got wrong input: got %q, want %q", got, "47|53\n")
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "day-5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Got leftover files in the cache: %v", entries)
+	}
+}
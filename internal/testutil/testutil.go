@@ -0,0 +1,107 @@
+// Package testutil holds the helpers shared by the tests and benchmarks of the
+// days, which need the testing package and so are kept out of the packages
+// used by the aoc command.
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/answers"
+	"github.com/tejesh-kaliki/advent-of-code-2024/bench"
+	"github.com/tejesh-kaliki/advent-of-code-2024/examples"
+	"github.com/tejesh-kaliki/advent-of-code-2024/inputs"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+// LoadOrSkip loads the input of a day from the cache, and skips the test or
+// benchmark if it is not there.
+func LoadOrSkip(tb testing.TB, day int) string {
+	tb.Helper()
+
+	input, err := inputs.Load(day, "")
+	if err != nil {
+		tb.Skip(err)
+	}
+	return input
+}
+
+// BenchPart benchmarks the registered solver of one part of a day on its
+// cached input. Besides the usual ns/op, it reports the min, median and max
+// time of the runs as custom metrics, which bench.ParseGoTest reads back into
+// a bench.Result.
+func BenchPart(b *testing.B, day, part int) {
+	b.Helper()
+
+	registered, found := registry.Get(day)
+	solver := registered.Part(part)
+	if !found || solver == nil {
+		b.Skipf("day %d, part %d is not solved", day, part)
+	}
+	input := LoadOrSkip(b, day)
+
+	b.ReportAllocs()
+	durations := make([]time.Duration, 0, b.N)
+	b.ResetTimer()
+	for range b.N {
+		start := time.Now()
+		if _, err := solver(context.Background(), input); err != nil {
+			b.Fatal(err)
+		}
+		durations = append(durations, time.Since(start))
+	}
+	b.StopTimer()
+
+	result := bench.Summarize(durations)
+	b.ReportMetric(float64(result.Min.Nanoseconds()), "min-ns")
+	b.ReportMetric(float64(result.Median.Nanoseconds()), "median-ns")
+	b.ReportMetric(float64(result.Max.Nanoseconds()), "max-ns")
+}
+
+// Examples runs the registered solvers of a day on the fixtures written by
+// "aoc examples" in the testdata directory of the test, and checks that they
+// return the expected answers.
+func Examples(t *testing.T, day int) {
+	t.Helper()
+
+	registered, found := registry.Get(day)
+	if !found {
+		t.Fatalf("day %d is not registered", day)
+	}
+
+	for part := 1; part <= 2; part++ {
+		solver := registered.Part(part)
+		t.Run(fmt.Sprintf("part %d", part), func(t *testing.T) {
+			input, want, found, err := examples.Load("testdata", part)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if !found || solver == nil {
+				t.Skipf("day %d, part %d has no example or no solver", day, part)
+			}
+
+			answer, err := solver(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if got := answers.Format(answer); got != want {
+				t.Errorf("Got wrong output: got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+// MustReadExample returns the content of an example in the testdata directory
+// of the test. It is meant for package level variables, so it panics on
+// errors.
+func MustReadExample(name string) string {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
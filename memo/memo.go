@@ -0,0 +1,168 @@
+// Package memo remembers the results of pure functions, like the counts of the
+// recursive solvers. A Memo is safe to use from several goroutines, can be
+// bounded in size, and counts its hits and misses.
+package memo
+
+import (
+	"fmt"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+)
+
+type Options[K any] struct {
+	// Number of shards, each with a lock of its own, so that goroutines using
+	// different keys rarely wait for each other. 1 if not set.
+	Shards int
+	// Hash of the keys, picking the shard of a key. Needed with more than one
+	// shard. HashString and HashInts cover the common keys.
+	Hash func(key K) uint64
+	// Most entries kept, or 0 for no limit. Once a shard is full, its oldest
+	// entry is evicted for every new one.
+	MaxEntries int
+}
+
+type Memo[K comparable, V any] struct {
+	shards []shard[K, V]
+	hash   func(key K) uint64
+
+	hits, misses, evictions atomic.Uint64
+}
+
+type shard[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]V
+	// Keys in the order they were added, when the shard is bounded. It is
+	// used as a ring, next being the oldest key.
+	order []K
+	next  int
+	limit int
+}
+
+func New[K comparable, V any](opts Options[K]) *Memo[K, V] {
+	shards := max(opts.Shards, 1)
+	if shards > 1 && opts.Hash == nil {
+		panic("memo: a hash function is needed with more than one shard")
+	}
+
+	m := &Memo[K, V]{shards: make([]shard[K, V], shards), hash: opts.Hash}
+	for i := range m.shards {
+		m.shards[i].entries = make(map[K]V)
+		if opts.MaxEntries > 0 {
+			m.shards[i].limit = max(opts.MaxEntries/shards, 1)
+		}
+	}
+	return m
+}
+
+func (m *Memo[K, V]) shard(key K) *shard[K, V] {
+	if len(m.shards) == 1 {
+		return &m.shards[0]
+	}
+	return &m.shards[m.hash(key)%uint64(len(m.shards))]
+}
+
+// Get returns the value of a key, and whether it was found.
+func (m *Memo[K, V]) Get(key K) (V, bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	value, found := s.entries[key]
+	s.mu.Unlock()
+
+	if found {
+		m.hits.Add(1)
+	} else {
+		m.misses.Add(1)
+	}
+	return value, found
+}
+
+// Set stores the value of a key.
+func (m *Memo[K, V]) Set(key K, value V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.entries[key]; found || s.limit == 0 {
+		s.entries[key] = value
+		return
+	}
+
+	if len(s.order) < s.limit {
+		s.order = append(s.order, key)
+	} else {
+		delete(s.entries, s.order[s.next])
+		s.order[s.next] = key
+		s.next = (s.next + 1) % s.limit
+		m.evictions.Add(1)
+	}
+	s.entries[key] = value
+}
+
+// Do returns the value of a key, computing and storing it if it is missing.
+// No lock is held while computing, so that compute can use the memo too. Two
+// goroutines missing the same key may both compute it.
+func (m *Memo[K, V]) Do(key K, compute func() V) V {
+	if value, found := m.Get(key); found {
+		return value
+	}
+	value := compute()
+	m.Set(key, value)
+	return value
+}
+
+// Len returns the number of entries.
+func (m *Memo[K, V]) Len() int {
+	total := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		total += len(s.entries)
+		s.mu.Unlock()
+	}
+	return total
+}
+
+type Stats struct {
+	Hits, Misses, Evictions uint64
+	Entries                 int
+}
+
+// HitRate returns the fraction of the lookups that were hits.
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("%d hits, %d misses (%.1f%% hits), %d entries, %d evictions",
+		s.Hits, s.Misses, 100*s.HitRate(), s.Entries, s.Evictions)
+}
+
+func (m *Memo[K, V]) Stats() Stats {
+	return Stats{
+		Hits:      m.hits.Load(),
+		Misses:    m.misses.Load(),
+		Evictions: m.evictions.Load(),
+		Entries:   m.Len(),
+	}
+}
+
+var seed = maphash.MakeSeed()
+
+func HashString(key string) uint64 {
+	return maphash.String(seed, key)
+}
+
+// HashInts hashes a list of integers, for keys made of a few numbers.
+func HashInts(values ...int64) uint64 {
+	h := uint64(14695981039346656037)
+	for _, value := range values {
+		h ^= uint64(value)
+		h *= 1099511628211
+		h ^= h >> 29
+	}
+	return h
+}
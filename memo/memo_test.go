@@ -0,0 +1,119 @@
+package memo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestGetAndSet(t *testing.T) {
+	m := New[string, int](Options[string]{})
+	if _, found := m.Get("a"); found {
+		t.Errorf("Found a key that was never set")
+	}
+	m.Set("a", 1)
+	m.Set("a", 2)
+	if value, found := m.Get("a"); !found || value != 2 {
+		t.Errorf("Got wrong value: got %d, %v, want 2, true", value, found)
+	}
+
+	want := Stats{Hits: 1, Misses: 1, Entries: 1}
+	if got := m.Stats(); got != want {
+		t.Errorf("Got wrong stats: got %+v, want %+v", got, want)
+	}
+}
+
+func TestDo(t *testing.T) {
+	m := New[int, int](Options[int]{})
+	calls := 0
+	var fib func(n int) int
+	fib = func(n int) int {
+		return m.Do(n, func() int {
+			calls++
+			if n < 2 {
+				return n
+			}
+			return fib(n-1) + fib(n-2)
+		})
+	}
+
+	if got := fib(50); got != 12586269025 {
+		t.Errorf("Got wrong output: got %d, want 12586269025", got)
+	}
+	if calls != 51 {
+		t.Errorf("Got wrong number of computations: got %d, want 51", calls)
+	}
+}
+
+func TestMaxEntriesEvictsTheOldest(t *testing.T) {
+	m := New[int, int](Options[int]{MaxEntries: 3})
+	for i := range 5 {
+		m.Set(i, i)
+	}
+	m.Set(4, 40)
+
+	for key, want := range map[int]bool{0: false, 1: false, 2: true, 3: true, 4: true} {
+		if _, found := m.Get(key); found != want {
+			t.Errorf("Got wrong presence of %d: got %v, want %v", key, found, want)
+		}
+	}
+	if stats := m.Stats(); stats.Entries != 3 || stats.Evictions != 2 {
+		t.Errorf("Got wrong stats: %+v", stats)
+	}
+}
+
+func TestShardsFromGoroutines(t *testing.T) {
+	m := New[string, int](Options[string]{Shards: 8, Hash: HashString, MaxEntries: 800})
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				key := fmt.Sprint(i % 100)
+				if value := m.Do(key, func() int { return i % 100 }); value != i%100 {
+					t.Errorf("goroutine %d: got %d for %s", g, value, key)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := m.Stats()
+	if stats.Entries != 100 || stats.Hits+stats.Misses != 8000 || stats.Misses < 100 {
+		t.Errorf("Got wrong stats: %+v", stats)
+	}
+}
+
+func TestNewNeedsAHashForShards(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New did not panic without a hash function")
+		}
+	}()
+	New[string, int](Options[string]{Shards: 4})
+}
+
+func TestStatsString(t *testing.T) {
+	stats := Stats{Hits: 3, Misses: 1, Entries: 1}
+	if got, want := stats.String(), "3 hits, 1 misses (75.0% hits), 1 entries, 0 evictions"; got != want {
+		t.Errorf("Got wrong output: got %q, want %q", got, want)
+	}
+}
+
+func TestReport(t *testing.T) {
+	Report(context.Background(), "ignored", Stats{Hits: 1})
+
+	collector := &Collector{}
+	ctx := NewContext(context.Background(), collector)
+	Report(ctx, "blinks", Stats{Hits: 2})
+	Report(ctx, "patterns", Stats{Misses: 3})
+
+	got := collector.Stats()
+	want := []NamedStats{{"blinks", Stats{Hits: 2}}, {"patterns", Stats{Misses: 3}}}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Got wrong stats: got %+v, want %+v", got, want)
+	}
+}
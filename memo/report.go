@@ -0,0 +1,42 @@
+package memo
+
+import (
+	"context"
+	"sync"
+)
+
+// Collector gathers the statistics of the memos used while solving a part,
+// so that the effectiveness of the caches can be shown next to the answer.
+type Collector struct {
+	mu    sync.Mutex
+	stats []NamedStats
+}
+
+type NamedStats struct {
+	Name string
+	Stats
+}
+
+type collectorKey struct{}
+
+// NewContext returns a context in which the solvers report to the collector.
+func NewContext(ctx context.Context, collector *Collector) context.Context {
+	return context.WithValue(ctx, collectorKey{}, collector)
+}
+
+// Report passes the statistics of a memo to the collector of the context, if
+// there is one.
+func Report(ctx context.Context, name string, stats Stats) {
+	if collector, ok := ctx.Value(collectorKey{}).(*Collector); ok {
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+		collector.stats = append(collector.stats, NamedStats{name, stats})
+	}
+}
+
+// Stats returns the reported statistics, in the order they were reported.
+func (c *Collector) Stats() []NamedStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]NamedStats(nil), c.stats...)
+}
@@ -0,0 +1,77 @@
+// Package parallel runs the independent pieces of a solver, like the patterns
+// of day 19, on a bounded number of goroutines. Unlike bare goroutines, it
+// stops once the context is done, and turns a panic into an error returned to
+// the solver, so that the runner can report it rather than crash.
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// PanicError is a panic of a piece of work, with the stack it happened in.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Workers returns the number of goroutines Map uses: one per CPU.
+func Workers() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// Map calls fn for every item on at most Workers goroutines, and returns the
+// results in the order of the items. Once the context is done, or fn fails or
+// panics for an item, no other item is started, and the first error is
+// returned after the items running have returned. fn is given a context that
+// is cancelled then, to check in long computations.
+func Map[T, R any](ctx context.Context, items []T, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make([]R, len(items))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(Workers(), len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= len(items) {
+					return
+				}
+				result, err := call(ctx, items[i], fn)
+				if err != nil {
+					cancel(err)
+					return
+				}
+				results[i] = result
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Calls fn, turning a panic into a PanicError.
+func call[T, R any](ctx context.Context, item T, fn func(ctx context.Context, item T) (R, error)) (result R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(ctx, item)
+}
@@ -0,0 +1,92 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+
+	var running, most atomic.Int64
+	got, err := Map(context.Background(), items, func(ctx context.Context, item int) (int, error) {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			seen := most.Load()
+			if now <= seen || most.CompareAndSwap(seen, now) {
+				break
+			}
+		}
+		return item * item, nil
+	})
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	for i, value := range got {
+		if value != i*i {
+			t.Fatalf("Got wrong result %d: got %d, want %d", i, value, i*i)
+		}
+	}
+	if most.Load() > int64(Workers()) {
+		t.Errorf("Got too many items at once: got %d, want at most %d", most.Load(), Workers())
+	}
+}
+
+func TestMapErrors(t *testing.T) {
+	failure := errors.New("failure")
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testcases := []struct {
+		Name string
+		Ctx  context.Context
+		Fn   func(ctx context.Context, item int) (int, error)
+		Want error
+	}{
+		{"error of an item", context.Background(), func(ctx context.Context, item int) (int, error) {
+			if item == 50 {
+				return 0, failure
+			}
+			return item, nil
+		}, failure},
+		{"context done", cancelled, func(ctx context.Context, item int) (int, error) {
+			return item, nil
+		}, context.Canceled},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			items := slices.Repeat([]int{1}, 100)
+			items[10] = 50
+			got, err := Map(testcase.Ctx, items, testcase.Fn)
+			if !errors.Is(err, testcase.Want) || got != nil {
+				t.Errorf("Got wrong output: got %v, %v, want error %v", got, err, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestMapRecoversPanics(t *testing.T) {
+	_, err := Map(context.Background(), []int{1, 2, 0, 3}, func(ctx context.Context, item int) (int, error) {
+		return 10 / item, nil
+	})
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Got wrong error: got %v, want a panic", err)
+	}
+	if want := "panic: runtime error: integer divide by zero"; err.Error() != want {
+		t.Errorf("Got wrong error: got %q, want %q", err.Error(), want)
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Errorf("Got no stack for the panic")
+	}
+}
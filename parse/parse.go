@@ -0,0 +1,150 @@
+// Package parse has the helpers shared by the input parsers of every day, so
+// that all of them handle line endings the same way and report errors with
+// the line and column where the input is wrong.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Error is an error at a specific place of the input. Line and Column start
+// at 1. Column is 0 when the error is about the whole line.
+type Error struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (err *Error) Error() string {
+	if err.Column == 0 {
+		return fmt.Sprintf("line %d: %v", err.Line, err.Err)
+	}
+	return fmt.Sprintf("line %d, column %d: %v", err.Line, err.Column, err.Err)
+}
+
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+func Errorf(line, column int, format string, args ...any) error {
+	return &Error{line, column, fmt.Errorf(format, args...)}
+}
+
+// Move an error of a text to where the text starts in the input. Errors that
+// are not [*Error] are returned as they are.
+func Offset(err error, line, column int) error {
+	var parseErr *Error
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+
+	moved := *parseErr
+	if moved.Line == 1 && moved.Column != 0 {
+		moved.Column += column - 1
+	}
+	moved.Line += line - 1
+	return &moved
+}
+
+// Normalize converts CRLF line endings to LF and drops the trailing newlines.
+func Normalize(input string) string {
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	return strings.TrimRight(input, "\n")
+}
+
+// Lines splits the input into lines, after normalizing it.
+// An empty input has no lines.
+func Lines(input string) []string {
+	input = Normalize(input)
+	if input == "" {
+		return nil
+	}
+	return strings.Split(input, "\n")
+}
+
+// A group of lines of the input, separated from the others by blank lines.
+type Block struct {
+	Text string
+	// The line of the input where the block starts
+	Line int
+}
+
+// Blocks splits the input into the groups of lines separated by blank lines.
+func Blocks(input string) []Block {
+	blocks := make([]Block, 0)
+	start := -1
+	lines := Lines(input)
+	for i, line := range lines {
+		switch {
+		case line == "" && start != -1:
+			blocks = append(blocks, Block{strings.Join(lines[start:i], "\n"), start + 1})
+			start = -1
+		case line != "" && start == -1:
+			start = i
+		}
+	}
+	if start != -1 {
+		blocks = append(blocks, Block{strings.Join(lines[start:], "\n"), start + 1})
+	}
+	return blocks
+}
+
+// A whitespace separated part of a line.
+type Field struct {
+	Text string
+	// The column of the line where the field starts
+	Column int
+}
+
+// Fields splits the line on spaces and tabs.
+func Fields(line string) []Field {
+	fields := make([]Field, 0)
+	start := -1
+	for i := 0; i <= len(line); i++ {
+		isSpace := i == len(line) || line[i] == ' ' || line[i] == '\t'
+		switch {
+		case isSpace && start != -1:
+			fields = append(fields, Field{line[start:i], start + 1})
+			start = -1
+		case !isSpace && start == -1:
+			start = i
+		}
+	}
+	return fields
+}
+
+// Int parses a decimal number found at the given line and column.
+func Int(text string, line, column int) (int, error) {
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, Errorf(line, column, "invalid number %q", text)
+	}
+	return value, nil
+}
+
+// Ints parses a line of whitespace separated numbers.
+func Ints(line string, lineNumber int) ([]int, error) {
+	fields := Fields(line)
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		value, err := Int(field.Text, lineNumber, field.Column)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Sscanf scans a line using the format, and fails unless all the arguments
+// are filled.
+func Sscanf(line string, lineNumber int, format string, args ...any) error {
+	n, err := fmt.Sscanf(line, format, args...)
+	if err != nil || n != len(args) {
+		return Errorf(lineNumber, 0, "%q does not match %q", line, format)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	testcases := []struct {
+		Name  string
+		Input string
+		Want  []string
+	}{
+		{"empty input has no lines", "", nil},
+		{"single line without newline", "abc", []string{"abc"}},
+		{"trailing newline is dropped", "abc\ndef\n", []string{"abc", "def"}},
+		{"multiple trailing newlines are dropped", "abc\n\n\n", []string{"abc"}},
+		{"CRLF is handled like LF", "abc\r\ndef\r\n", []string{"abc", "def"}},
+		{"blank lines in the middle are kept", "abc\n\ndef", []string{"abc", "", "def"}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got := Lines(testcase.Input)
+			if !slices.Equal(got, testcase.Want) {
+				t.Errorf("Got wrong lines: got %q, want %q", got, testcase.Want)
+			}
+		})
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	got := Blocks("a\nb\n\nc\r\n\r\n\r\nd\ne\n")
+	want := []Block{{"a\nb", 1}, {"c", 4}, {"d\ne", 7}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong blocks: got %v, want %v", got, want)
+	}
+}
+
+func TestFields(t *testing.T) {
+	got := Fields("3   4\t 15 ")
+	want := []Field{{"3", 1}, {"4", 5}, {"15", 8}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Got wrong fields: got %v, want %v", got, want)
+	}
+}
+
+func TestInts(t *testing.T) {
+	got, err := Ints("1 22  333", 4)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if !slices.Equal(got, []int{1, 22, 333}) {
+		t.Errorf("Got wrong values: got %v", got)
+	}
+
+	_, err = Ints("1 2x 3", 4)
+	if want := `line 4, column 3: invalid number "2x"`; err == nil || err.Error() != want {
+		t.Errorf("Got wrong error: got %v, want %s", err, want)
+	}
+}
+
+func TestSscanf(t *testing.T) {
+	var x, y int
+	if err := Sscanf("p=1,2", 3, "p=%d,%d", &x, &y); err != nil || x != 1 || y != 2 {
+		t.Errorf("Got wrong output: got %d, %d (error %v), want 1, 2", x, y, err)
+	}
+
+	err := Sscanf("p=1", 3, "p=%d,%d", &x, &y)
+	var parseErr *Error
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+		t.Errorf("Got wrong error: got %v, want error at line 3", err)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	err := Offset(Errorf(1, 4, "bad"), 10, 3)
+	if want := "line 10, column 6: bad"; err.Error() != want {
+		t.Errorf("Got wrong error: got %q, want %q", err, want)
+	}
+
+	err = Offset(Errorf(2, 4, "bad"), 10, 3)
+	if want := "line 11, column 4: bad"; err.Error() != want {
+		t.Errorf("Got wrong error: got %q, want %q", err, want)
+	}
+
+	_, numErr := strconv.Atoi("x")
+	if got := Offset(numErr, 10, 3); got != numErr {
+		t.Errorf("Got wrong error: got %v, want it unchanged", got)
+	}
+}
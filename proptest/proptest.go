@@ -0,0 +1,94 @@
+// Package proptest checks the solvers against slow but obviously correct
+// reference solvers, called oracles, on random inputs. The inputs come from
+// the generators of the days, seeded by testing/quick. Run the tests with
+// -quickchecks to change the number of inputs, 100 by default, and with
+// -proptest.seed to generate the same inputs again.
+package proptest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	oldrand "math/rand"
+	"math/rand/v2"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/answers"
+	"github.com/tejesh-kaliki/advent-of-code-2024/registry"
+)
+
+var seedFlag = flag.Int64("proptest.seed", 0, "seed of the random inputs of the property tests, 0 for a new one")
+
+// Generator returns a random puzzle input.
+type Generator func(rng *rand.Rand) string
+
+// Oracle returns the answers of both parts of a puzzle. An answer is nil for
+// a part that it does not check.
+type Oracle func(input string) (part1, part2 any)
+
+// NewRand returns the random generator used for a seed, to reproduce a
+// failing input.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, 0))
+}
+
+// Check runs the check on inputs generated from random seeds, and fails the
+// test with the seed and the input of the first input it fails on. The seeds
+// come from the -proptest.seed flag, or from the time, which is logged so
+// that a failing test can be run again on the same inputs.
+func Check(t *testing.T, generate Generator, check func(input string) error) {
+	t.Helper()
+
+	seeds := *seedFlag
+	if seeds == 0 {
+		seeds = time.Now().UnixNano()
+	}
+	t.Logf("random inputs from -proptest.seed=%d", seeds)
+
+	property := func(seed uint64) bool {
+		input := generate(NewRand(seed))
+		if err := check(input); err != nil {
+			t.Errorf("seed %d: %v\ninput:\n%s", seed, err, input)
+			return false
+		}
+		return true
+	}
+	config := &quick.Config{Rand: oldrand.New(oldrand.NewSource(seeds))}
+	if err := quick.Check(property, config); err != nil {
+		if _, failed := err.(*quick.CheckError); !failed {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+	}
+}
+
+// CheckParts checks that the registered solvers of a day give the same
+// answers as the oracle.
+func CheckParts(t *testing.T, day int, generate Generator, oracle Oracle) {
+	t.Helper()
+
+	registered, found := registry.Get(day)
+	if !found {
+		t.Fatalf("day %d is not registered", day)
+	}
+
+	Check(t, generate, func(input string) error {
+		part1, part2 := oracle(input)
+		for i, want := range []any{part1, part2} {
+			solver := registered.Part(i + 1)
+			if want == nil || solver == nil {
+				continue
+			}
+
+			got, err := solver(context.Background(), input)
+			if err != nil {
+				return fmt.Errorf("part %d: %w", i+1, err)
+			}
+			if answers.Format(got) != answers.Format(want) {
+				return fmt.Errorf("part %d: got %s, want %s", i+1, answers.Format(got), answers.Format(want))
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,44 @@
+package proptest
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestNewRandIsReproducible(t *testing.T) {
+	first, second := NewRand(42), NewRand(42)
+	for range 10 {
+		if a, b := first.Uint64(), second.Uint64(); a != b {
+			t.Fatalf("Got different numbers for the same seed: %d and %d", a, b)
+		}
+	}
+}
+
+func TestCheckGeneratesDifferentInputs(t *testing.T) {
+	inputs := make(map[string]bool)
+	Check(t, func(rng *rand.Rand) string { return strconv.Itoa(rng.IntN(1000)) }, func(input string) error {
+		inputs[input] = true
+		return nil
+	})
+	if len(inputs) < 50 {
+		t.Errorf("Got too few distinct inputs: %d", len(inputs))
+	}
+}
+
+func TestCheckWithSeedGeneratesSameInputs(t *testing.T) {
+	defer func(seed int64) { *seedFlag = seed }(*seedFlag)
+	*seedFlag = 42
+
+	var runs [2][]string
+	for i := range runs {
+		Check(t, func(rng *rand.Rand) string { return strconv.Itoa(rng.IntN(1000)) }, func(input string) error {
+			runs[i] = append(runs[i], input)
+			return nil
+		})
+	}
+	if !slices.Equal(runs[0], runs[1]) {
+		t.Errorf("Got different inputs for the same seed: %v and %v", runs[0], runs[1])
+	}
+}
@@ -0,0 +1,65 @@
+// Package registry keeps track of the solvers of every day, so that they can
+// all be run the same way from a single command.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+// Solver computes the answer of one part of a puzzle from the puzzle input.
+// It fails if the input cannot be parsed. Slow solvers should stop and return
+// ctx.Err() once the context is done.
+type Solver func(ctx context.Context, input string) (any, error)
+
+// Generator returns a random input with the size and the structure of the real
+// inputs, keeping the guarantees of the puzzle, so that the solvers can be run
+// without the real inputs. The same random source gives the same input.
+type Generator func(rng *rand.Rand) string
+
+type Day struct {
+	Number int
+	Part1  Solver
+	Part2  Solver
+	// Generate is nil for the days without a generator.
+	Generate Generator
+}
+
+// Returns the solver for the given part, or nil if the part is not solved yet.
+func (day Day) Part(part int) Solver {
+	switch part {
+	case 1:
+		return day.Part1
+	case 2:
+		return day.Part2
+	}
+	return nil
+}
+
+var days = map[int]Day{}
+
+// Register adds the solvers of a day. It is meant to be called from the init
+// function of each day's package, and panics if the day is registered twice.
+func Register(day Day) {
+	if _, found := days[day.Number]; found {
+		panic(fmt.Sprintf("day %d is already registered", day.Number))
+	}
+	days[day.Number] = day
+}
+
+func Get(number int) (Day, bool) {
+	day, found := days[number]
+	return day, found
+}
+
+// Returns all the registered days, ordered by day number.
+func All() []Day {
+	all := make([]Day, 0, len(days))
+	for _, day := range days {
+		all = append(all, day)
+	}
+	slices.SortFunc(all, func(a, b Day) int { return a.Number - b.Number })
+	return all
+}
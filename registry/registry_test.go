@@ -0,0 +1,41 @@
+package registry
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() { days = map[int]Day{} })
+
+	Register(Day{Number: 3, Part1: func(context.Context, string) (any, error) { return 3, nil }})
+	Register(Day{Number: 1, Part1: func(context.Context, string) (any, error) { return 1, nil }})
+
+	day, found := Get(3)
+	if !found || day.Number != 3 {
+		t.Fatalf("Got wrong day: got %v (found %v), want day 3", day.Number, found)
+	}
+	if day.Part(2) != nil {
+		t.Errorf("Got a solver for part 2, want nil")
+	}
+	if got, _ := day.Part(1)(context.Background(), ""); got != 3 {
+		t.Errorf("Got wrong output: got %v, want %d", got, 3)
+	}
+
+	all := All()
+	if len(all) != 2 || all[0].Number != 1 || all[1].Number != 3 {
+		t.Errorf("Got wrong days: got %v", all)
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	t.Cleanup(func() { days = map[int]Day{} })
+
+	Register(Day{Number: 1})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Registering a day twice did not panic")
+		}
+	}()
+	Register(Day{Number: 1})
+}
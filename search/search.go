@@ -0,0 +1,257 @@
+// Package search finds shortest paths in graphs given by a neighbour function,
+// so that the graph never has to be built: the nodes can be grid positions,
+// positions with a direction, or any other comparable state.
+//
+// Every search returns a Result, with the distance to every node it reached
+// and the way back to the start, from which the paths are rebuilt.
+package search
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Neighbours returns the nodes reachable from a node in a single step.
+type Neighbours[N comparable] func(node N) []N
+
+// Edge is a step to a node, costing Cost. Costs must not be negative.
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+// WeightedNeighbours returns the steps that can be taken from a node.
+type WeightedNeighbours[N comparable] func(node N) []Edge[N]
+
+// Unit gives every step of an unweighted graph a cost of 1.
+func Unit[N comparable](neighbours Neighbours[N]) WeightedNeighbours[N] {
+	return func(node N) []Edge[N] {
+		next := neighbours(node)
+		edges := make([]Edge[N], len(next))
+		for i, to := range next {
+			edges[i] = Edge[N]{To: to, Cost: 1}
+		}
+		return edges
+	}
+}
+
+// Result holds the shortest distances from the start to the nodes reached by
+// a search, and the nodes coming before each node on its shortest paths.
+type Result[N comparable] struct {
+	start N
+	dist  map[N]int
+	// The nodes before each node on its shortest paths. Only the search for
+	// all the shortest paths keeps more than one.
+	parents map[N][]N
+	order   []N
+}
+
+func newResult[N comparable](start N) *Result[N] {
+	return &Result[N]{
+		start:   start,
+		dist:    map[N]int{start: 0},
+		parents: make(map[N][]N),
+		order:   []N{start},
+	}
+}
+
+func (r *Result[N]) Start() N {
+	return r.start
+}
+
+// Distance returns the length of the shortest path to a node, and whether the
+// node was reached.
+func (r *Result[N]) Distance(node N) (int, bool) {
+	dist, found := r.dist[node]
+	return dist, found
+}
+
+func (r *Result[N]) Reached(node N) bool {
+	_, found := r.dist[node]
+	return found
+}
+
+// Distances returns the distance map: the length of the shortest path to
+// every node reached. The map must not be changed.
+func (r *Result[N]) Distances() map[N]int {
+	return r.dist
+}
+
+// Nodes returns the nodes reached, in the order they were found. The slice
+// must not be changed.
+func (r *Result[N]) Nodes() []N {
+	return r.order
+}
+
+// Path returns a shortest path from the start to a node, both included, or
+// nil if the node was not reached.
+func (r *Result[N]) Path(to N) []N {
+	if !r.Reached(to) {
+		return nil
+	}
+
+	path := []N{to}
+	for node := to; node != r.start; {
+		node = r.parents[node][0]
+		path = append(path, node)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// Paths returns every shortest path from the start to a node. It only returns
+// more than one path for the results of AllShortestPaths.
+func (r *Result[N]) Paths(to N) [][]N {
+	if !r.Reached(to) {
+		return nil
+	}
+	if to == r.start {
+		return [][]N{{to}}
+	}
+
+	paths := make([][]N, 0)
+	for _, parent := range r.parents[to] {
+		for _, path := range r.Paths(parent) {
+			paths = append(paths, append(path, to))
+		}
+	}
+	return paths
+}
+
+// CountPaths returns the number of shortest paths from the start to a node,
+// without listing them. It only counts more than one path for the results of
+// AllShortestPaths.
+func (r *Result[N]) CountPaths(to N) int {
+	counts := map[N]int{r.start: 1}
+	var count func(node N) int
+	count = func(node N) int {
+		if total, found := counts[node]; found {
+			return total
+		}
+		total := 0
+		for _, parent := range r.parents[node] {
+			total += count(parent)
+		}
+		counts[node] = total
+		return total
+	}
+
+	if !r.Reached(to) {
+		return 0
+	}
+	return count(to)
+}
+
+// BFS finds the shortest paths from the start to every node it can reach,
+// every step costing 1.
+func BFS[N comparable](start N, neighbours Neighbours[N]) *Result[N] {
+	result := newResult(start)
+	for i := 0; i < len(result.order); i++ {
+		node := result.order[i]
+		for _, next := range neighbours(node) {
+			if result.Reached(next) {
+				continue
+			}
+			result.dist[next] = result.dist[node] + 1
+			result.parents[next] = []N{node}
+			result.order = append(result.order, next)
+		}
+	}
+	return result
+}
+
+// Dijkstra finds the shortest paths from the start to every node it can
+// reach.
+func Dijkstra[N comparable](start N, neighbours WeightedNeighbours[N]) *Result[N] {
+	return run(start, neighbours, nil, nil, false)
+}
+
+// AStar finds a shortest path from the start to the goal, exploring the nodes
+// that look closer to the goal first. The heuristic estimates the cost from a
+// node to the goal, and must never overestimate it, nor drop by more than the
+// cost of a step. The search stops once the goal is reached, so the result
+// only has the distances of the nodes explored before.
+func AStar[N comparable](start, goal N, neighbours WeightedNeighbours[N], heuristic func(node N) int) *Result[N] {
+	return run(start, neighbours, heuristic, &goal, false)
+}
+
+// AllShortestPaths finds every shortest path from the start to every node it
+// can reach, for Paths and CountPaths. The steps must cost more than 0, or a
+// node could be on its own paths.
+func AllShortestPaths[N comparable](start N, neighbours WeightedNeighbours[N]) *Result[N] {
+	return run(start, neighbours, nil, nil, true)
+}
+
+// Runs Dijkstra's algorithm, or A* with a heuristic, stopping at the goal if
+// there is one. With all, every parent on a shortest path is kept.
+func run[N comparable](start N, neighbours WeightedNeighbours[N], heuristic func(N) int, goal *N, all bool) *Result[N] {
+	result := newResult(start)
+	result.order = result.order[:0]
+	estimate := func(node N) int {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(node)
+	}
+
+	done := make(map[N]bool)
+	queue := &priorityQueue[N]{{node: start, priority: estimate(start)}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(queueItem[N])
+		if done[item.node] || item.dist != result.dist[item.node] {
+			continue
+		}
+		done[item.node] = true
+		result.order = append(result.order, item.node)
+		if goal != nil && item.node == *goal {
+			break
+		}
+
+		for _, edge := range neighbours(item.node) {
+			dist := item.dist + edge.Cost
+			current, found := result.dist[edge.To]
+			switch {
+			case !found || dist < current:
+				result.dist[edge.To] = dist
+				result.parents[edge.To] = []N{item.node}
+				heap.Push(queue, queueItem[N]{node: edge.To, dist: dist, priority: dist + estimate(edge.To)})
+			case all && dist == current && edge.To != start:
+				result.parents[edge.To] = append(result.parents[edge.To], item.node)
+			}
+		}
+	}
+
+	// Drop the nodes seen but not explored, whose distance may still be too
+	// long when stopping at the goal.
+	for node := range result.dist {
+		if !done[node] {
+			delete(result.dist, node)
+			delete(result.parents, node)
+		}
+	}
+	return result
+}
+
+type queueItem[N comparable] struct {
+	node     N
+	dist     int
+	priority int
+}
+
+// A min-heap of the nodes to explore, by priority.
+type priorityQueue[N comparable] []queueItem[N]
+
+func (q priorityQueue[N]) Len() int           { return len(q) }
+func (q priorityQueue[N]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q priorityQueue[N]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue[N]) Push(x any) {
+	*q = append(*q, x.(queueItem[N]))
+}
+
+func (q *priorityQueue[N]) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
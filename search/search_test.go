@@ -0,0 +1,218 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+)
+
+type Position = grid.Position
+
+// Returns the open neighbours of a position in a maze, where '#' is a wall.
+func mazeNeighbours(t *testing.T, maze string) (grid.Grid[rune], Neighbours[Position]) {
+	t.Helper()
+	cells, err := grid.ParseRunes(strings.TrimSpace(maze))
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	return cells, func(pos Position) []Position {
+		next := make([]Position, 0, 4)
+		for _, neighbour := range cells.Neighbours4(pos) {
+			if cells.At(neighbour) != '#' {
+				next = append(next, neighbour)
+			}
+		}
+		return next
+	}
+}
+
+const maze = `
+..#....
+.##.##.
+...#...
+.#...#.
+.......`
+
+func TestBFS(t *testing.T) {
+	_, neighbours := mazeNeighbours(t, maze)
+	result := BFS(Position{X: 0, Y: 0}, neighbours)
+
+	testcases := []struct {
+		Name     string
+		To       Position
+		Want     int
+		WantPath bool
+	}{
+		{Name: "start", To: Position{X: 0, Y: 0}, Want: 0, WantPath: true},
+		{Name: "corridor", To: Position{X: 0, Y: 4}, Want: 4, WantPath: true},
+		{Name: "around the walls", To: Position{X: 6, Y: 0}, Want: 12, WantPath: true},
+		{Name: "dead end", To: Position{X: 1, Y: 0}, Want: 1, WantPath: true},
+		{Name: "wall", To: Position{X: 2, Y: 0}, WantPath: false},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			got, found := result.Distance(testcase.To)
+			if found != testcase.WantPath || got != testcase.Want {
+				t.Errorf("Got wrong output: got %d, %v, want %d, %v", got, found, testcase.Want, testcase.WantPath)
+			}
+
+			path := result.Path(testcase.To)
+			if !testcase.WantPath {
+				if path != nil {
+					t.Errorf("Got a path to an unreachable node: %v", path)
+				}
+				return
+			}
+			checkPath(t, path, Position{X: 0, Y: 0}, testcase.To, neighbours)
+			if len(path) != testcase.Want+1 {
+				t.Errorf("Got wrong path length: got %d, want %d", len(path), testcase.Want+1)
+			}
+		})
+	}
+}
+
+// Checks that every step of the path is allowed, from start to end.
+func checkPath(t *testing.T, path []Position, start, end Position, neighbours Neighbours[Position]) {
+	t.Helper()
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != end {
+		t.Fatalf("Got wrong path from %v to %v: %v", start, end, path)
+	}
+	for i := 1; i < len(path); i++ {
+		if !slices.Contains(neighbours(path[i-1]), path[i]) {
+			t.Errorf("Got wrong step from %v to %v", path[i-1], path[i])
+		}
+	}
+}
+
+func TestBFSOrder(t *testing.T) {
+	_, neighbours := mazeNeighbours(t, "...\n...")
+	result := BFS(Position{X: 0, Y: 0}, neighbours)
+
+	previous := 0
+	for _, node := range result.Nodes() {
+		dist, _ := result.Distance(node)
+		if dist < previous {
+			t.Errorf("Got %v at distance %d after a node at distance %d", node, dist, previous)
+		}
+		previous = dist
+	}
+	if got := len(result.Distances()); got != 6 {
+		t.Errorf("Got wrong number of nodes: got %d, want 6", got)
+	}
+}
+
+// A graph where the shortest path has more steps than the direct edge.
+var weighted = map[string][]Edge[string]{
+	"a": {{To: "b", Cost: 7}, {To: "c", Cost: 2}},
+	"b": {{To: "d", Cost: 1}},
+	"c": {{To: "b", Cost: 3}, {To: "d", Cost: 8}},
+	"d": {},
+	"e": {{To: "a", Cost: 1}},
+}
+
+func weightedNeighbours(node string) []Edge[string] {
+	return weighted[node]
+}
+
+func TestDijkstra(t *testing.T) {
+	result := Dijkstra("a", weightedNeighbours)
+
+	testcases := []struct {
+		To       string
+		Want     int
+		WantPath []string
+	}{
+		{To: "a", Want: 0, WantPath: []string{"a"}},
+		{To: "b", Want: 5, WantPath: []string{"a", "c", "b"}},
+		{To: "c", Want: 2, WantPath: []string{"a", "c"}},
+		{To: "d", Want: 6, WantPath: []string{"a", "c", "b", "d"}},
+		{To: "e", Want: 0, WantPath: nil},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.To, func(t *testing.T) {
+			if got, _ := result.Distance(testcase.To); got != testcase.Want {
+				t.Errorf("Got wrong output: got %d, want %d", got, testcase.Want)
+			}
+			if got := result.Path(testcase.To); !slices.Equal(got, testcase.WantPath) {
+				t.Errorf("Got wrong path: got %v, want %v", got, testcase.WantPath)
+			}
+		})
+	}
+}
+
+func TestAStar(t *testing.T) {
+	cells, neighbours := mazeNeighbours(t, maze)
+	start, goal := Position{X: 0, Y: 0}, Position{X: 6, Y: 4}
+	heuristic := func(pos Position) int { return grid.ManhattanDistance(pos, goal) }
+
+	result := AStar(start, goal, Unit(neighbours), heuristic)
+	want, _ := BFS(start, neighbours).Distance(goal)
+	if got, _ := result.Distance(goal); got != want {
+		t.Errorf("Got wrong output: got %d, want %d", got, want)
+	}
+	checkPath(t, result.Path(goal), start, goal, neighbours)
+
+	open := len(cells.FindAll(func(cell rune) bool { return cell != '#' }))
+	if explored := len(result.Nodes()); explored >= open {
+		t.Errorf("Got every one of the %d open cells explored", explored)
+	}
+	if result.Reached(Position{X: 6, Y: 0}) {
+		t.Errorf("Got the corner away from the goal explored")
+	}
+}
+
+func TestAStarUnreachable(t *testing.T) {
+	_, neighbours := mazeNeighbours(t, "..#.\n..#.")
+	result := AStar(Position{X: 0, Y: 0}, Position{X: 3, Y: 0}, Unit(neighbours), func(Position) int { return 0 })
+	if result.Reached(Position{X: 3, Y: 0}) || result.Path(Position{X: 3, Y: 0}) != nil {
+		t.Errorf("Got a path to an unreachable goal")
+	}
+	if got := len(result.Nodes()); got != 4 {
+		t.Errorf("Got wrong number of nodes: got %d, want 4", got)
+	}
+}
+
+func TestAllShortestPaths(t *testing.T) {
+	_, neighbours := mazeNeighbours(t, "...\n.#.\n...")
+	start, end := Position{X: 0, Y: 0}, Position{X: 2, Y: 2}
+	result := AllShortestPaths(start, Unit(neighbours))
+
+	testcases := []struct {
+		Name string
+		To   Position
+		Want int
+	}{
+		{Name: "start", To: start, Want: 1},
+		{Name: "straight line", To: Position{X: 2, Y: 0}, Want: 1},
+		{Name: "around the wall", To: end, Want: 2},
+		{Name: "wall", To: Position{X: 1, Y: 1}, Want: 0},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if got := result.CountPaths(testcase.To); got != testcase.Want {
+				t.Errorf("Got wrong output: got %d, want %d", got, testcase.Want)
+			}
+			paths := result.Paths(testcase.To)
+			if len(paths) != testcase.Want {
+				t.Errorf("Got wrong number of paths: got %d, want %d", len(paths), testcase.Want)
+			}
+			for _, path := range paths {
+				checkPath(t, path, start, testcase.To, neighbours)
+			}
+		})
+	}
+}
+
+func TestAllShortestPathsGrid(t *testing.T) {
+	_, neighbours := mazeNeighbours(t, "....\n....\n....\n....")
+	result := AllShortestPaths(Position{X: 0, Y: 0}, Unit(neighbours))
+	// Choosing the 3 steps right among the 6 steps.
+	if got := result.CountPaths(Position{X: 3, Y: 3}); got != 20 {
+		t.Errorf("Got wrong output: got %d, want 20", got)
+	}
+}
@@ -0,0 +1,218 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+const (
+	clearScreen = "\x1b[2J"
+	cursorHome  = "\x1b[H"
+	clearLine   = "\x1b[K"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+)
+
+// The keys sent by the arrows, the other keys being sent as themselves.
+var arrows = map[string]string{
+	"\x1b[A": "up",
+	"\x1b[B": "down",
+	"\x1b[C": "right",
+	"\x1b[D": "left",
+}
+
+const help = "space: pause  left/right: step  [/]: 10 steps  {/}: 100 steps  0/$: first/last  +/-: speed  q: quit"
+
+// ReadKeys sends the keys read from r, a terminal in raw mode, on the returned
+// channel. The channel is closed once r fails or ends.
+func ReadKeys(r io.Reader) <-chan string {
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 64)
+		for {
+			n, err := r.Read(buf)
+			for _, key := range parseKeys(buf[:n]) {
+				keys <- key
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return keys
+}
+
+// Splits the bytes read from the terminal into keys. Ctrl-C is read as "q".
+func parseKeys(data []byte) []string {
+	keys := make([]string, 0)
+	for len(data) > 0 {
+		if len(data) >= 3 {
+			if arrow, found := arrows[string(data[:3])]; found {
+				keys = append(keys, arrow)
+				data = data[3:]
+				continue
+			}
+		}
+		if data[0] == 3 {
+			keys = append(keys, "q")
+			data = data[1:]
+			continue
+		}
+		_, size := utf8.DecodeRune(data)
+		keys = append(keys, string(data[:size]))
+		data = data[size:]
+	}
+	return keys
+}
+
+// Player plays the steps of a simulation, one frame per step, redrawing the
+// terminal in place.
+type Player struct {
+	Steps    []trace.Step[grid.Grid[rune]]
+	Renderer Renderer
+	FPS      int
+
+	current int
+	paused  bool
+}
+
+// Current returns the position of the step on screen in Steps.
+func (p *Player) Current() int {
+	return p.current
+}
+
+func (p *Player) Paused() bool {
+	return p.paused
+}
+
+// HandleKey applies a key, and reports whether the player should go on.
+// Stepping one step at a time pauses the playback.
+func (p *Player) HandleKey(key string) bool {
+	switch key {
+	case "q":
+		return false
+	case " ":
+		p.paused = !p.paused
+		if !p.paused && p.current == len(p.Steps)-1 {
+			p.current = 0
+		}
+	case "right", "l":
+		p.seek(1)
+		p.paused = true
+	case "left", "h":
+		p.seek(-1)
+		p.paused = true
+	case "]", "up":
+		p.seek(10)
+	case "[", "down":
+		p.seek(-10)
+	case "}":
+		p.seek(100)
+	case "{":
+		p.seek(-100)
+	case "0":
+		p.current = 0
+	case "$":
+		p.current = len(p.Steps) - 1
+	case "+":
+		p.FPS = min(p.FPS*2, 1000)
+	case "-":
+		p.FPS = max(p.FPS/2, 1)
+	}
+	return true
+}
+
+func (p *Player) seek(delta int) {
+	p.current = min(max(p.current+delta, 0), len(p.Steps)-1)
+}
+
+func (p *Player) delay() time.Duration {
+	return time.Second / time.Duration(max(p.FPS, 1))
+}
+
+// Play draws the steps at the speed of the player, until the key "q" is
+// pressed. Without keys, it returns after the last step. The playback stops
+// on the last step, which can be left by seeking back.
+func (p *Player) Play(ctx context.Context, keys <-chan string, out io.Writer) error {
+	if len(p.Steps) == 0 {
+		return errors.New("no steps to play")
+	}
+	last := len(p.Steps) - 1
+
+	fmt.Fprint(out, hideCursor+clearScreen)
+	defer fmt.Fprint(out, showCursor)
+
+	ticker := time.NewTicker(p.delay())
+	defer ticker.Stop()
+
+	for redraw := true; ; {
+		if redraw {
+			if err := p.draw(out); err != nil {
+				return err
+			}
+		}
+		if keys == nil && p.current == last {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case key, ok := <-keys:
+			if !ok {
+				keys, p.paused = nil, false
+				redraw = false
+				continue
+			}
+			fps := p.FPS
+			if !p.HandleKey(key) {
+				return nil
+			}
+			if p.FPS != fps {
+				ticker.Reset(p.delay())
+			}
+			redraw = true
+		case <-ticker.C:
+			redraw = !p.paused && p.current < last
+			if redraw {
+				p.current++
+			}
+		}
+	}
+}
+
+// Draws the current step over the previous one, with a status line below.
+func (p *Player) draw(out io.Writer) error {
+	step := p.Steps[p.current]
+	var buf bytes.Buffer
+	buf.WriteString(cursorHome)
+	if err := p.Renderer.Render(&buf, step.State); err != nil {
+		return err
+	}
+
+	state := "playing"
+	switch {
+	case p.current == len(p.Steps)-1:
+		state = "end"
+	case p.paused:
+		state = "paused"
+	}
+	label := fmt.Sprintf("step %d (%d of %d)", step.Index, p.current+1, len(p.Steps))
+	if step.Action != "" {
+		label += ": " + step.Action
+	}
+	fmt.Fprintf(&buf, "%s, %s at %d fps%s\n", label, state, p.FPS, clearLine)
+	fmt.Fprintf(&buf, "%s%s\n", help, clearLine)
+
+	_, err := out.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,109 @@
+// Package terminal draws grids in a terminal with ANSI colours, and plays the
+// steps of a simulation with keys to pause, step and seek. It only needs a
+// terminal understanding ANSI escape codes and the stty command.
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+)
+
+type ColorMode int
+
+const (
+	// Plain text, for files, pipes and NO_COLOR.
+	NoColor ColorMode = iota
+	// The 16 colours that every terminal, even the Linux console, has.
+	Basic
+	// 24 bit colours, for terminals setting COLORTERM to truecolor.
+	TrueColor
+)
+
+// DetectColorMode returns the colours that can be written to a file, following
+// the NO_COLOR and COLORTERM conventions.
+func DetectColorMode(f *os.File) ColorMode {
+	if !IsTerminal(f) || os.Getenv("NO_COLOR") != "" {
+		return NoColor
+	}
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+	return Basic
+}
+
+// Renderer draws grids with a colour for each kind of cell, taken from the
+// same palette as the animations.
+type Renderer struct {
+	Palette animate.Palette
+	Mode    ColorMode
+}
+
+// Render writes every row of the grid on a line of its own.
+func (r Renderer) Render(w io.Writer, frame grid.Grid[rune]) error {
+	out := bufio.NewWriter(w)
+	for _, row := range frame.Cells {
+		previous := ""
+		for _, cell := range row {
+			if code := r.escape(cell); code != previous {
+				out.WriteString(code)
+				previous = code
+			}
+			out.WriteRune(cell)
+		}
+		if previous != "" {
+			out.WriteString(reset)
+		}
+		out.WriteString("\n")
+	}
+	return out.Flush()
+}
+
+const reset = "\x1b[0m"
+
+// Returns the escape code setting the colour of a cell.
+func (r Renderer) escape(cell rune) string {
+	c := r.Palette.Color(cell)
+	switch r.Mode {
+	case TrueColor:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	case Basic:
+		return fmt.Sprintf("\x1b[%dm", nearestBasic(c))
+	}
+	return ""
+}
+
+// The 16 basic colours, as the VGA console shows them, by SGR code.
+var basicColors = map[int]color.RGBA{
+	30: {0x00, 0x00, 0x00, 0xFF}, 31: {0xAA, 0x00, 0x00, 0xFF},
+	32: {0x00, 0xAA, 0x00, 0xFF}, 33: {0xAA, 0x55, 0x00, 0xFF},
+	34: {0x00, 0x00, 0xAA, 0xFF}, 35: {0xAA, 0x00, 0xAA, 0xFF},
+	36: {0x00, 0xAA, 0xAA, 0xFF}, 37: {0xAA, 0xAA, 0xAA, 0xFF},
+	90: {0x55, 0x55, 0x55, 0xFF}, 91: {0xFF, 0x55, 0x55, 0xFF},
+	92: {0x55, 0xFF, 0x55, 0xFF}, 93: {0xFF, 0xFF, 0x55, 0xFF},
+	94: {0x55, 0x55, 0xFF, 0xFF}, 95: {0xFF, 0x55, 0xFF, 0xFF},
+	96: {0x55, 0xFF, 0xFF, 0xFF}, 97: {0xFF, 0xFF, 0xFF, 0xFF},
+}
+
+// Returns the SGR code of the basic colour closest to c. Black is skipped, as
+// it would hide the cells on a dark terminal.
+func nearestBasic(c color.RGBA) int {
+	best, bestDistance := 37, -1
+	for code, basic := range basicColors {
+		if code == 30 {
+			continue
+		}
+		dr, dg, db := int(c.R)-int(basic.R), int(c.G)-int(basic.G), int(c.B)-int(basic.B)
+		distance := dr*dr + dg*dg + db*db
+		if bestDistance < 0 || distance < bestDistance || (distance == bestDistance && code < best) {
+			best, bestDistance = code, distance
+		}
+	}
+	return best
+}
@@ -0,0 +1,175 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tejesh-kaliki/advent-of-code-2024/animate"
+	"github.com/tejesh-kaliki/advent-of-code-2024/grid"
+	"github.com/tejesh-kaliki/advent-of-code-2024/trace"
+)
+
+func mustParseGrid(t *testing.T, text string) grid.Grid[rune] {
+	t.Helper()
+	cells, err := grid.ParseRunes(text)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	return cells
+}
+
+func TestRender(t *testing.T) {
+	palette := animate.Palette{'#': {0xFF, 0xFF, 0xFF, 0xFF}, '.': {0x00, 0x00, 0xAA, 0xFF}}
+	testcases := []struct {
+		Name string
+		Mode ColorMode
+		Want string
+	}{
+		{
+			Name: "no colour",
+			Mode: NoColor,
+			Want: "##.\n",
+		},
+		{
+			Name: "basic colours",
+			Mode: Basic,
+			Want: "\x1b[97m##\x1b[34m.\x1b[0m\n",
+		},
+		{
+			Name: "true colours",
+			Mode: TrueColor,
+			Want: "\x1b[38;2;255;255;255m##\x1b[38;2;0;0;170m.\x1b[0m\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Renderer{Palette: palette, Mode: testcase.Mode}.Render(&buf, mustParseGrid(t, "##."))
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if buf.String() != testcase.Want {
+				t.Errorf("Got wrong output: got %q, want %q", buf.String(), testcase.Want)
+			}
+		})
+	}
+}
+
+func TestNearestBasic(t *testing.T) {
+	testcases := []struct {
+		Color color.RGBA
+		Want  int
+	}{
+		{color.RGBA{0xF0, 0x30, 0x30, 0xFF}, 91},
+		{color.RGBA{0x20, 0xE0, 0x40, 0xFF}, 92},
+		{color.RGBA{0x80, 0x80, 0x88, 0xFF}, 37},
+		{color.RGBA{0x00, 0x00, 0x00, 0xFF}, 90},
+	}
+
+	for _, testcase := range testcases {
+		if got := nearestBasic(testcase.Color); got != testcase.Want {
+			t.Errorf("Got wrong colour for %v: got %d, want %d", testcase.Color, got, testcase.Want)
+		}
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("q \x1b[C\x1b[D]\x03é"))
+	want := []string{"q", " ", "right", "left", "]", "q", "é"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong keys: got %q, want %q", got, want)
+	}
+}
+
+func TestReadKeys(t *testing.T) {
+	got := make([]string, 0)
+	for key := range ReadKeys(strings.NewReader("l\x1b[A")) {
+		got = append(got, key)
+	}
+	if !reflect.DeepEqual(got, []string{"l", "up"}) {
+		t.Errorf("Got wrong keys: got %q", got)
+	}
+}
+
+func steps(n int) []trace.Step[grid.Grid[rune]] {
+	all := make([]trace.Step[grid.Grid[rune]], n)
+	for i := range all {
+		all[i] = trace.Step[grid.Grid[rune]]{Index: i, Action: "tick", State: grid.New(2, 1, '.')}
+	}
+	return all
+}
+
+func TestHandleKey(t *testing.T) {
+	testcases := []struct {
+		Name       string
+		Keys       []string
+		Want       int
+		WantPaused bool
+		WantFPS    int
+	}{
+		{"step forward pauses", []string{"right", "l"}, 2, true, 10},
+		{"step back stops at the start", []string{"left"}, 0, true, 10},
+		{"seek by 10 and 100", []string{"}", "]", "[", "["}, 90, false, 10},
+		{"seek stops at the end", []string{"}", "}"}, 149, false, 10},
+		{"first and last", []string{"$", "0", "]"}, 10, false, 10},
+		{"pause and resume", []string{" ", " ", " "}, 0, true, 10},
+		{"resume at the end restarts", []string{"$", " ", " "}, 0, false, 10},
+		{"speed", []string{"+", "+", "-"}, 0, false, 20},
+		{"slowest speed", []string{"-", "-", "-", "-", "-"}, 0, false, 1},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			player := &Player{Steps: steps(150), FPS: 10}
+			for _, key := range testcase.Keys {
+				if !player.HandleKey(key) {
+					t.Fatalf("Player quit on key %q", key)
+				}
+			}
+			if player.Current() != testcase.Want || player.Paused() != testcase.WantPaused || player.FPS != testcase.WantFPS {
+				t.Errorf("Got wrong state: got step %d, paused %v, %d fps, want step %d, paused %v, %d fps",
+					player.Current(), player.Paused(), player.FPS, testcase.Want, testcase.WantPaused, testcase.WantFPS)
+			}
+		})
+	}
+
+	if (&Player{Steps: steps(1)}).HandleKey("q") {
+		t.Errorf("Player did not quit on q")
+	}
+}
+
+func TestPlayWithoutKeys(t *testing.T) {
+	var out bytes.Buffer
+	player := &Player{Steps: steps(3), FPS: 1000}
+	if err := player.Play(context.Background(), nil, &out); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"step 0 (1 of 3): tick, playing", "step 1 (2 of 3): tick, playing", "step 2 (3 of 3): tick, end"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Output does not have %q:\n%s", want, out.String())
+		}
+	}
+	if !strings.HasSuffix(out.String(), showCursor) {
+		t.Errorf("Cursor is not shown again after playing")
+	}
+}
+
+func TestPlayQuits(t *testing.T) {
+	keys := make(chan string, 2)
+	keys <- "right"
+	keys <- "q"
+	var out bytes.Buffer
+	player := &Player{Steps: steps(100), FPS: 1}
+	if err := player.Play(context.Background(), keys, &out); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if player.Current() != 1 || !strings.Contains(out.String(), "step 1 (2 of 100): tick, paused") {
+		t.Errorf("Got wrong output, at step %d:\n%s", player.Current(), out.String())
+	}
+}
@@ -0,0 +1,93 @@
+// Package trace lets the simulations of the days report every step they take,
+// so that they can be recorded, replayed, drawn or debugged without changing
+// the solvers.
+//
+// A simulation calls the Observe method of its observer after every step,
+// with the index of the step, the action taken and a snapshot of its state.
+// The snapshot must not share memory with the simulation, as the observer
+// may keep it.
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Step is one step of a simulation whose state is of type S.
+type Step[S any] struct {
+	Index  int    `json:"step"`
+	Action string `json:"action"`
+	State  S      `json:"state"`
+}
+
+// Observer is told about every step of a simulation.
+type Observer[S any] interface {
+	Observe(step Step[S])
+}
+
+// ObserverFunc turns a function into an Observer.
+type ObserverFunc[S any] func(step Step[S])
+
+func (f ObserverFunc[S]) Observe(step Step[S]) {
+	f(step)
+}
+
+// Recorder keeps every step in memory.
+type Recorder[S any] struct {
+	Steps []Step[S]
+}
+
+func (r *Recorder[S]) Observe(step Step[S]) {
+	r.Steps = append(r.Steps, step)
+}
+
+// Actions returns the action of every recorded step.
+func (r *Recorder[S]) Actions() []string {
+	actions := make([]string, len(r.Steps))
+	for i, step := range r.Steps {
+		actions[i] = step.Action
+	}
+	return actions
+}
+
+// JSONLWriter writes every step as a line of JSON. The simulations cannot
+// handle errors of their observers, so the first error is kept, and can be
+// checked with Err once the simulation is done. The steps after an error are
+// dropped.
+type JSONLWriter[S any] struct {
+	encoder *json.Encoder
+	err     error
+}
+
+// NewJSONLWriter returns an observer writing the steps to w.
+func NewJSONLWriter[S any](w io.Writer) *JSONLWriter[S] {
+	return &JSONLWriter[S]{encoder: json.NewEncoder(w)}
+}
+
+func (w *JSONLWriter[S]) Observe(step Step[S]) {
+	if w.err == nil {
+		w.err = w.encoder.Encode(step)
+	}
+}
+
+// Err returns the first error met while writing the steps.
+func (w *JSONLWriter[S]) Err() error {
+	return w.err
+}
+
+// ReadJSONL reads back the steps written by a JSONLWriter, to replay them.
+func ReadJSONL[S any](r io.Reader) ([]Step[S], error) {
+	steps := make([]Step[S], 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64<<20)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		var step Step[S]
+		if err := json.Unmarshal(scanner.Bytes(), &step); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		steps = append(steps, step)
+	}
+	return steps, scanner.Err()
+}
@@ -0,0 +1,86 @@
+package trace
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type position struct {
+	X, Y int
+}
+
+func TestJSONLWriterAndReader(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewJSONLWriter[position](&buf)
+	writer.Observe(Step[position]{Index: 0, Action: "up", State: position{1, 2}})
+	writer.Observe(Step[position]{Index: 1, Action: "turn", State: position{1, 1}})
+	if err := writer.Err(); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	wantText := `{"step":0,"action":"up","state":{"X":1,"Y":2}}
+{"step":1,"action":"turn","state":{"X":1,"Y":1}}
+`
+	if buf.String() != wantText {
+		t.Errorf("Got wrong trace:\n%s\nwant:\n%s", buf.String(), wantText)
+	}
+
+	got, err := ReadJSONL[position](&buf)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	want := []Step[position]{{0, "up", position{1, 2}}, {1, "turn", position{1, 1}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got wrong steps: got %v, want %v", got, want)
+	}
+}
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("disk full")
+}
+
+func TestJSONLWriterKeepsFirstError(t *testing.T) {
+	out := &failingWriter{}
+	writer := NewJSONLWriter[int](out)
+	writer.Observe(Step[int]{Index: 0, State: 1})
+	writer.Observe(Step[int]{Index: 1, State: 2})
+
+	if err := writer.Err(); err == nil || err.Error() != "disk full" {
+		t.Errorf("Got wrong error: got %v, want disk full", err)
+	}
+	if out.writes != 1 {
+		t.Errorf("Got wrong number of writes: got %d, want 1", out.writes)
+	}
+}
+
+func TestReadJSONLErrors(t *testing.T) {
+	_, err := ReadJSONL[int](strings.NewReader("{\"step\":0,\"state\":1}\n{\"step\":1,\"state\":\"x\"}\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2: ") {
+		t.Errorf("Got wrong error: got %v, want an error on line 2", err)
+	}
+}
+
+func TestRecorderAndObserverFunc(t *testing.T) {
+	recorder := &Recorder[int]{}
+	count := 0
+	observers := []Observer[int]{recorder, ObserverFunc[int](func(step Step[int]) { count++ })}
+	for _, observer := range observers {
+		observer.Observe(Step[int]{Index: 0, Action: "a"})
+		observer.Observe(Step[int]{Index: 1, Action: "b"})
+	}
+
+	if got := recorder.Actions(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Got wrong actions: got %v", got)
+	}
+	if count != 2 {
+		t.Errorf("Got wrong number of calls: got %d, want 2", count)
+	}
+}